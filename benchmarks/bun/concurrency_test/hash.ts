@@ -0,0 +1,11 @@
+// XOR every hash into acc, so the digest does not depend on how the work was split
+export function hashRange(start: number, end: number): Uint8Array {
+  const acc = new Uint8Array(32);
+  for (let i = start; i < end; i++) {
+    const hash = new Bun.CryptoHasher('sha256').update(`data_${i}`).digest();
+    for (let b = 0; b < 32; b++) {
+      acc[b] ^= hash[b];
+    }
+  }
+  return acc;
+}
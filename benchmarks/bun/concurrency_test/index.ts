@@ -1,3 +1,5 @@
+import { hashRange } from './hash';
+
 // Simple argument parser
 const args = process.argv.slice(2).reduce((acc: Record<string, string>, arg: string) => {
   const [key, value] = arg.split('=');
@@ -12,18 +14,11 @@ const WORKLOAD = parseInt(args.workload || '1000000', 10);
 
 async function run() {
   const startTime = process.hrtime.bigint();
+  let digest = new Uint8Array(32);
 
   if (MODE === 'single') {
-    // Single-threaded execution - simple hash computation
-    let acc = 0;
-    for (let i = 0; i < WORKLOAD; i++) {
-      const data = `data_${i}`;
-      const hash = Bun.hash(data);
-      // Convert hash to number if it's a bigint
-      acc ^= typeof hash === "bigint" ? Number(hash) : hash;
-    }
-    // Use acc so it is not optimized away
-    if (Number.isNaN(acc)) process.exit(1);
+    // Single-threaded execution - SHA-256 like the other runtimes
+    digest = hashRange(0, WORKLOAD);
   } else if (MODE === 'multi') {
     // Multi-threaded execution using Web Workers
     const numCPU = navigator.hardwareConcurrency || 4;
@@ -34,17 +29,24 @@ async function run() {
       const start = cpu * workPerCPU;
       const end = cpu === numCPU - 1 ? WORKLOAD : start + workPerCPU;
       
-      const worker = new Worker(new URL('./worker.ts', import.meta.url), {
-        data: { start, end }
-      });
-      
-      workers.push(new Promise((resolve, reject) => {
-        worker.onmessage = resolve;
+      const worker = new Worker(new URL('./worker.ts', import.meta.url));
+
+      workers.push(new Promise<Uint8Array>((resolve, reject) => {
+        worker.onmessage = (event: MessageEvent) => {
+          resolve(event.data);
+          worker.terminate();
+        };
         worker.onerror = reject;
       }));
+      worker.postMessage({ start, end });
+    }
+
+    const partials = await Promise.all(workers);
+    for (const partial of partials) {
+      for (let b = 0; b < 32; b++) {
+        digest[b] ^= partial[b];
+      }
     }
-    
-    await Promise.all(workers);
   } else {
     console.error(`Invalid mode: ${MODE}. Use 'single' or 'multi'`);
     process.exit(1);
@@ -60,6 +62,7 @@ async function run() {
     totalTimeMs: totalTimeMs,
     operationsPerSecond: opsPerSecond,
     mode: MODE,
+    digest: Buffer.from(digest).toString('hex'),
   }));
 }
 
@@ -0,0 +1,8 @@
+import { hashRange } from './hash';
+
+declare var self: Worker;
+
+self.onmessage = (event: MessageEvent) => {
+  const { start, end } = event.data;
+  postMessage(hashRange(start, end));
+};
@@ -0,0 +1,146 @@
+import { open, unlink, type FileHandle } from 'node:fs/promises';
+
+// Simple argument parser
+const args = process.argv.slice(2).reduce((acc: Record<string, string>, arg: string) => {
+  const [key, value] = arg.split('=');
+  if (key && value) {
+    acc[key.substring(2)] = value;
+  }
+  return acc;
+}, {});
+
+const OUTPUT_PATH = args.output || 'test_data/temp_concurrent.dat';
+const WORKERS = parseInt(args.workers || '4', 10);
+const OPERATIONS = parseInt(args.operations || '4096', 10);
+const BLOCK_SIZE = parseInt(args['block-size'] || '4096', 10);
+const FILE_SIZE = parseInt(args['file-size'] || String(16 * 1024 * 1024), 10);
+const PATTERN = args.pattern || 'sequential';
+const IO = args.io || 'mixed';
+
+if (!['sequential', 'random'].includes(PATTERN)) {
+  console.error(`Invalid pattern: ${PATTERN}. Use 'sequential' or 'random'`);
+  process.exit(1);
+}
+if (!['read', 'write', 'mixed'].includes(IO)) {
+  console.error(`Invalid io mode: ${IO}. Use 'read', 'write' or 'mixed'`);
+  process.exit(1);
+}
+if (WORKERS < 1 || OPERATIONS < 1 || BLOCK_SIZE < 1 || FILE_SIZE < BLOCK_SIZE) {
+  console.error('workers, operations and block-size must be positive and file-size at least block-size');
+  process.exit(1);
+}
+const BLOCKS = Math.floor(FILE_SIZE / BLOCK_SIZE);
+
+function workerPath(id: number): string {
+  return `${OUTPUT_PATH}.${id}`;
+}
+
+// xorshift32, matching the Go reference so every runtime visits the same offsets
+function xorshift(id: number): () => number {
+  let state = Math.imul(id + 1, 2654435761) >>> 0;
+  return () => {
+    state = (state ^ (state << 13)) >>> 0;
+    state = (state ^ (state >>> 17)) >>> 0;
+    state = (state ^ (state << 5)) >>> 0;
+    return state;
+  };
+}
+
+// Block k of worker id holds the byte k*7+id
+async function createWorkerFile(id: number): Promise<FileHandle> {
+  const handle = await open(workerPath(id), 'w+');
+  const buffer = Buffer.alloc(BLOCK_SIZE);
+  for (let k = 0; k < BLOCKS; k++) {
+    buffer.fill((k * 7 + id) & 0xff);
+    await handle.write(buffer, 0, BLOCK_SIZE);
+  }
+  return handle;
+}
+
+// Each worker runs its operations one after another; the workers themselves run concurrently
+async function runWorker(id: number, handle: FileHandle, latencies: Float64Array) {
+  const buffer = Buffer.alloc(BLOCK_SIZE);
+  const next = xorshift(id);
+
+  for (let i = 0; i < OPERATIONS; i++) {
+    const block = PATTERN === 'random' ? next() % BLOCKS : i % BLOCKS;
+    const offset = block * BLOCK_SIZE;
+
+    const write = IO === 'write' || (IO === 'mixed' && i % 2 === 1);
+    if (write) {
+      buffer.fill((id * 31 + i) & 0xff);
+    }
+
+    const start = process.hrtime.bigint();
+    if (write) {
+      await handle.write(buffer, 0, BLOCK_SIZE, offset);
+    } else {
+      await handle.read(buffer, 0, BLOCK_SIZE, offset);
+    }
+    latencies[i] = Number(process.hrtime.bigint() - start) / 1e6;
+  }
+}
+
+function percentile(sorted: Float64Array, p: number): number {
+  if (sorted.length === 0) {
+    return 0;
+  }
+  return sorted[Math.max(0, Math.ceil((p / 100) * sorted.length) - 1)];
+}
+
+async function runBenchmark() {
+  // Lay out every worker file before timing starts
+  const handles: FileHandle[] = [];
+  for (let id = 0; id < WORKERS; id++) {
+    handles.push(await createWorkerFile(id));
+  }
+  const latencies = handles.map(() => new Float64Array(OPERATIONS));
+
+  const startTime = process.hrtime.bigint();
+  await Promise.all(handles.map((handle, id) => runWorker(id, handle, latencies[id])));
+  const endTime = process.hrtime.bigint();
+
+  for (const handle of handles) {
+    await handle.close();
+  }
+
+  // The digest covers the final contents of every worker file in order
+  const hasher = new Bun.CryptoHasher('sha256');
+  for (let id = 0; id < WORKERS; id++) {
+    hasher.update(await Bun.file(workerPath(id)).arrayBuffer());
+    await unlink(workerPath(id));
+  }
+
+  const all = new Float64Array(WORKERS * OPERATIONS);
+  latencies.forEach((samples, id) => all.set(samples, id * OPERATIONS));
+  all.sort();
+  const latencyTotal = all.reduce((sum, value) => sum + value, 0);
+
+  const totalOps = WORKERS * OPERATIONS;
+  const totalTimeMs = Number(endTime - startTime) / 1e6;
+  const iops = totalOps / (totalTimeMs / 1000);
+  const mbPerSecond = (totalOps * BLOCK_SIZE) / 1024 / 1024 / (totalTimeMs / 1000);
+
+  console.log(JSON.stringify({
+    operations: totalOps,
+    totalTimeMs: totalTimeMs,
+    operationsPerSecond: iops,
+    iops: iops,
+    mbPerSecond: mbPerSecond,
+    latencyAvgMs: latencyTotal / all.length,
+    latencyP50Ms: percentile(all, 50),
+    latencyP90Ms: percentile(all, 90),
+    latencyP95Ms: percentile(all, 95),
+    latencyP99Ms: percentile(all, 99),
+    workers: WORKERS,
+    blockSize: BLOCK_SIZE,
+    pattern: PATTERN,
+    io: IO,
+    digest: hasher.digest('hex'),
+  }));
+}
+
+runBenchmark().catch((err) => {
+  console.error(err);
+  process.exit(1);
+});
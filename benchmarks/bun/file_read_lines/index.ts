@@ -11,21 +11,47 @@ const parseArgs = process.argv.slice(2).reduce((acc: Record<string, string>, arg
 
 const filePath = parseArgs.file || 'test_data/xlarge_lines.txt';
 const iterations = parseInt(parseArgs.iterations || '10', 10);
+const CACHE = parseArgs.cache || 'warm';
 
-async function readFileLines(path: string): Promise<number> {
-  const file = Bun.file(path);
-  const text = await file.text();
-  const lines = text.split('\n');
-  // Filter out empty lines
-  return lines.filter((line: string) => line.trim()).length;
+// Bun has no posix_fadvise or aligned buffers, so only warm cached reads are supported
+if (CACHE !== 'warm' || parseArgs.direct === 'true') {
+  console.error('Cold cache and direct I/O reads are not supported by the Bun benchmark');
+  process.exit(1);
+}
+
+// Every technology splits on \n, drops a trailing \r and skips empty lines
+function* splitLines(text: string): Generator<string> {
+  for (let line of text.split('\n')) {
+    if (line.endsWith('\r')) {
+      line = line.slice(0, -1);
+    }
+    if (line.length > 0) {
+      yield line;
+    }
+  }
+}
+
+// When hasher is given every counted line is fed to it followed by a newline
+async function readFileLines(path: string, hasher?: Bun.CryptoHasher): Promise<number> {
+  const text = await Bun.file(path).text();
+  let count = 0;
+  for (const line of splitLines(text)) {
+    if (hasher) {
+      hasher.update(line + '\n');
+    }
+    count++;
+  }
+  return count;
 }
 
 async function run() {
+  // The digest hashes the lines read in the first iteration
+  const hasher = new Bun.CryptoHasher('sha256');
   const startTime = process.hrtime.bigint();
   let totalLines = 0;
 
   for (let i = 0; i < iterations; i++) {
-    const lineCount = await readFileLines(filePath);
+    const lineCount = await readFileLines(filePath, i === 0 ? hasher : undefined);
     if (i === 0) {
       totalLines = lineCount;
     }
@@ -41,6 +67,9 @@ async function run() {
     totalTimeMs: totalTimeMs,
     operationsPerSecond: opsPerSecond,
     linesPerFile: totalLines,
+    digest: hasher.digest('hex'),
+    direct: false,
+    cache: CACHE,
   }));
 }
 
@@ -1,3 +1,6 @@
+import { constants } from 'node:fs';
+import { open } from 'node:fs/promises';
+
 // Simple argument parser
 const args = process.argv.slice(2).reduce((acc: Record<string, string>, arg: string) => {
   const [key, value] = arg.split('=');
@@ -10,6 +13,45 @@ const args = process.argv.slice(2).reduce((acc: Record<string, string>, arg: str
 const OUTPUT_PATH = args.output || 'test_data/temp_output.txt';
 const ITERATIONS = parseInt(args.iterations || '1000', 10);
 const DATA_SIZE = parseInt(args.size || '1024', 10);
+const SYNC = args.sync || 'none';
+
+if (!['none', 'fsync', 'fdatasync', 'osync'].includes(SYNC)) {
+  console.error(`Invalid sync mode: ${SYNC}. Use 'none', 'fsync', 'fdatasync' or 'osync'`);
+  process.exit(1);
+}
+if (args.direct === 'true') {
+  console.error('Direct I/O is not supported by the Bun benchmark');
+  process.exit(1);
+}
+
+// Write data to filePath and make it durable according to SYNC
+async function writeOutput(filePath: string, data: string | Uint8Array) {
+  if (SYNC === 'none') {
+    await Bun.write(filePath, data);
+    return;
+  }
+
+  const { O_WRONLY, O_CREAT, O_TRUNC, O_SYNC } = constants;
+  const flags = SYNC === 'osync' ? O_WRONLY | O_CREAT | O_TRUNC | O_SYNC : 'w';
+  const handle = await open(filePath, flags);
+  try {
+    await handle.writeFile(data);
+    if (SYNC === 'fsync') {
+      await handle.sync();
+    } else if (SYNC === 'fdatasync') {
+      await handle.datasync();
+    }
+  } finally {
+    await handle.close();
+  }
+}
+
+// Hex SHA-256 of a file, compared across technologies by the runner
+async function fileDigest(path: string): Promise<string> {
+  const hasher = new Bun.CryptoHasher('sha256');
+  hasher.update(await Bun.file(path).arrayBuffer());
+  return hasher.digest('hex');
+}
 
 async function run() {
   // Create test data
@@ -22,18 +64,24 @@ async function run() {
 
   // Write the data multiple times
   for (let i = 0; i < ITERATIONS; i++) {
-    await Bun.write(OUTPUT_PATH, testData);
+    await writeOutput(OUTPUT_PATH, testData);
   }
 
   const endTime = process.hrtime.bigint();
   const totalTimeMs = Number(endTime - startTime) / 1_000_000;
   const opsPerSecond = (ITERATIONS / totalTimeMs) * 1000;
 
+  // Read the output back so the digest covers what actually reached the file
+  const digest = await fileDigest(OUTPUT_PATH);
+
   // Output JSON result to stdout
   console.log(JSON.stringify({
     operations: ITERATIONS,
     totalTimeMs: totalTimeMs,
     operationsPerSecond: opsPerSecond,
+    digest: digest,
+    direct: false,
+    sync: SYNC,
   }));
 }
 
@@ -1,5 +1,8 @@
 /// <reference types="bun-types" />
 
+import { constants } from 'node:fs';
+import { open } from 'node:fs/promises';
+
 // Simple argument parser
 const parseArgs = process.argv.slice(2).reduce((acc: Record<string, string>, arg: string) => {
   const [key, value] = arg.split('=');
@@ -12,6 +15,38 @@ const parseArgs = process.argv.slice(2).reduce((acc: Record<string, string>, arg
 const outputPath = parseArgs.output || 'test_data/temp_output_lines.txt';
 const iterations = parseInt(parseArgs.iterations || '10', 10);
 const lineCount = parseInt(parseArgs.lines || '1000000', 10);
+const SYNC = parseArgs.sync || 'none';
+
+if (!['none', 'fsync', 'fdatasync', 'osync'].includes(SYNC)) {
+  console.error(`Invalid sync mode: ${SYNC}. Use 'none', 'fsync', 'fdatasync' or 'osync'`);
+  process.exit(1);
+}
+if (parseArgs.direct === 'true') {
+  console.error('Direct I/O is not supported by the Bun benchmark');
+  process.exit(1);
+}
+
+// Write data to filePath and make it durable according to SYNC
+async function writeOutput(filePath: string, data: string | Uint8Array) {
+  if (SYNC === 'none') {
+    await Bun.write(filePath, data);
+    return;
+  }
+
+  const { O_WRONLY, O_CREAT, O_TRUNC, O_SYNC } = constants;
+  const flags = SYNC === 'osync' ? O_WRONLY | O_CREAT | O_TRUNC | O_SYNC : 'w';
+  const handle = await open(filePath, flags);
+  try {
+    await handle.writeFile(data);
+    if (SYNC === 'fsync') {
+      await handle.sync();
+    } else if (SYNC === 'fdatasync') {
+      await handle.datasync();
+    }
+  } finally {
+    await handle.close();
+  }
+}
 
 const sampleTexts = [
   "Lorem ipsum dolor sit amet, consectetur adipiscing elit.",
@@ -21,6 +56,13 @@ const sampleTexts = [
   "Excepteur sint occaecat cupidatat non proident, sunt in culpa.",
 ];
 
+// Hex SHA-256 of a file, compared across technologies by the runner
+async function fileDigest(path: string): Promise<string> {
+  const hasher = new Bun.CryptoHasher('sha256');
+  hasher.update(await Bun.file(path).arrayBuffer());
+  return hasher.digest('hex');
+}
+
 async function run() {
   const startTime = process.hrtime.bigint();
 
@@ -33,13 +75,17 @@ async function run() {
       content += `Line ${lineNumber}: ${sampleTexts[textIndex]}\n`;
     }
 
-    await Bun.write(outputPath, content);
+    await writeOutput(outputPath, content);
   }
 
   const endTime = process.hrtime.bigint();
   const totalTimeMs = Number(endTime - startTime) / 1_000_000;
   const opsPerSecond = (iterations / totalTimeMs) * 1000;
 
+  // Read the output back before cleaning up so the digest covers what
+  // actually reached the file
+  const digest = await fileDigest(outputPath);
+
   // Clean up temp file
   try {
     await Bun.write(outputPath, '');
@@ -53,6 +99,9 @@ async function run() {
     totalTimeMs: totalTimeMs,
     operationsPerSecond: opsPerSecond,
     linesPerIteration: lineCount,
+    digest: digest,
+    direct: false,
+    sync: SYNC,
   }));
 }
 
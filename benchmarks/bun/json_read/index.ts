@@ -0,0 +1,190 @@
+/// <reference types="bun-types" />
+
+// Simple argument parser
+const parseArgs = process.argv.slice(2).reduce((acc: Record<string, string>, arg: string) => {
+  const [key, value] = arg.split('=');
+  if (key && value) {
+    acc[key.substring(2)] = value;
+  }
+  return acc;
+}, {});
+
+const shape = parseArgs.shape || 'flat';
+const decodeMode = parseArgs['decode-mode'] || 'untyped';
+const iterations = parseInt(parseArgs.iterations || '10', 10);
+
+type Decoder = (record: any) => any;
+
+// Typed records copy exactly the fields of the Go structs for each shape,
+// the way decoding into a struct drops everything else
+const pick = (o: any, ...keys: string[]) => Object.fromEntries(keys.map((key) => [key, o?.[key]]));
+const shapes: Record<string, Decoder> = {
+  flat: (o) => pick(o, 'id', 'name', 'email', 'active', 'score', 'age', 'country', 'category', 'created_at'),
+  nested: (o) => ({
+    id: o.id,
+    user: {
+      id: o.user?.id,
+      name: o.user?.name,
+      address: {
+        ...pick(o.user?.address, 'street', 'city', 'zip'),
+        geo: pick(o.user?.address?.geo, 'lat', 'lng'),
+      },
+    },
+    order: {
+      items: (o.order?.items || []).map((item: any) => ({
+        ...pick(item, 'sku', 'quantity', 'price'),
+        attributes: pick(item.attributes, 'color', 'size'),
+      })),
+      totals: pick(o.order?.totals, 'subtotal', 'tax', 'total'),
+    },
+    meta: {
+      tags: o.meta?.tags,
+      history: (o.meta?.history || []).map((event: any) => pick(event, 'timestamp', 'event')),
+    },
+  }),
+  numeric: (o) => ({ ...pick(o, 'id', 'values', 'counts'), stats: pick(o.stats, 'min', 'max', 'mean', 'stddev') }),
+  string: (o) => pick(o, 'id', 'title', 'body', 'author', 'url', 'tags'),
+};
+
+if (!shapes[shape]) {
+  console.error(`Invalid shape: ${shape}. Use 'flat', 'nested', 'numeric' or 'string'`);
+  process.exit(1);
+}
+if (!['typed', 'untyped', 'streaming'].includes(decodeMode)) {
+  console.error(`Invalid decode mode: ${decodeMode}. Use 'typed', 'untyped' or 'streaming'`);
+  process.exit(1);
+}
+
+const filePath = parseArgs.file || `test_data/shape_${shape}.json`;
+
+// arrayElements yields the source text of every element of a JSON array
+// document, so each one can be parsed on its own without materializing the
+// whole array
+function* arrayElements(text: string): Generator<string> {
+  let i = skipWhitespace(text, 0);
+  if (text[i] !== '[') {
+    throw new Error('expected a JSON array');
+  }
+  i = skipWhitespace(text, i + 1);
+  if (text[i] === ']') {
+    return;
+  }
+
+  for (;;) {
+    const start = i;
+    let depth = 0;
+    let inString = false;
+    for (; i < text.length; i++) {
+      const c = text[i];
+      if (inString) {
+        if (c === '\\') {
+          i++;
+        } else if (c === '"') {
+          inString = false;
+        }
+      } else if (c === '"') {
+        inString = true;
+      } else if (c === '{' || c === '[') {
+        depth++;
+      } else if (c === '}' || c === ']') {
+        if (depth === 0) {
+          break;
+        }
+        depth--;
+      } else if (c === ',' && depth === 0) {
+        break;
+      }
+    }
+    if (i >= text.length) {
+      throw new Error('unexpected end of JSON array');
+    }
+    yield text.slice(start, i);
+
+    if (text[i] === ']') {
+      return;
+    }
+    i = skipWhitespace(text, i + 1);
+  }
+}
+
+function skipWhitespace(text: string, i: number): number {
+  while (i < text.length && /\s/.test(text[i])) {
+    i++;
+  }
+  return i;
+}
+
+// parseDocument decodes the document and returns how many records it held.
+// When hasher is given the id of every decoded record is fed to it.
+function parseDocument(text: string, hasher?: Bun.CryptoHasher): number {
+  let records: any[] = [];
+  switch (decodeMode) {
+    case 'typed':
+      records = JSON.parse(text).map(shapes[shape]);
+      break;
+    case 'untyped':
+      records = JSON.parse(text);
+      if (!Array.isArray(records)) {
+        return 1;
+      }
+      break;
+    case 'streaming': {
+      let count = 0;
+      for (const element of arrayElements(text)) {
+        const record = shapes[shape](JSON.parse(element));
+        if (hasher) {
+          hasher.update(`${record.id}\n`);
+        }
+        count++;
+      }
+      return count;
+    }
+  }
+  if (hasher) {
+    for (const record of records) {
+      hasher.update(`${record?.id ?? 0}\n`);
+    }
+  }
+  return records.length;
+}
+
+async function run() {
+  const file = Bun.file(filePath);
+  if (!(await file.exists())) {
+    console.error(`File not found: ${filePath}`);
+    process.exit(1);
+  }
+
+  // Load the document once so every mode parses from memory
+  const text = await file.text();
+
+  // The digest hashes the ids decoded in the first iteration
+  const hasher = new Bun.CryptoHasher('sha256');
+  const startTime = process.hrtime.bigint();
+  let recordCount = 0;
+
+  for (let i = 0; i < iterations; i++) {
+    recordCount = parseDocument(text, i === 0 ? hasher : undefined);
+  }
+
+  const endTime = process.hrtime.bigint();
+  const totalTimeMs = Number(endTime - startTime) / 1_000_000;
+  const opsPerSecond = (iterations / totalTimeMs) * 1000;
+
+  // Output JSON result to stdout
+  console.log(JSON.stringify({
+    operations: iterations,
+    totalTimeMs: totalTimeMs,
+    operationsPerSecond: opsPerSecond,
+    records: recordCount,
+    bytes: file.size,
+    decodeMode: decodeMode,
+    shape: shape,
+    digest: hasher.digest('hex'),
+  }));
+}
+
+run().catch(err => {
+  console.error(`Error parsing JSON: ${(err as Error).message}`);
+  process.exit(1);
+});
@@ -9,33 +9,124 @@ const parseArgs = process.argv.slice(2).reduce((acc: Record<string, string>, arg
   return acc;
 }, {});
 
-const filePath = parseArgs.file || 'test_data/xlarge_lines.json';
+const shape = parseArgs.shape || 'items';
+const decodeMode = parseArgs['decode-mode'] || 'untyped';
 const iterations = parseInt(parseArgs.iterations || '10', 10);
 
-async function readJsonLines(path: string): Promise<number> {
-  const file = Bun.file(path);
-  const text = await file.text();
-  const lines = text.split('\n').filter((line: string) => line.trim());
-  
-  let lineCount = 0;
+type Decoder = (record: any) => any;
+
+// Typed records copy exactly the fields of the Go structs for each shape,
+// the way decoding into a struct drops everything else
+const pick = (o: any, ...keys: string[]) => Object.fromEntries(keys.map((key) => [key, o?.[key]]));
+const shapes: Record<string, Decoder> = {
+  items: (o) => pick(o, 'id', 'name', 'description', 'category', 'priority', 'tags', 'timestamp', 'value', 'active'),
+  flat: (o) => pick(o, 'id', 'name', 'email', 'active', 'score', 'age', 'country', 'category', 'created_at'),
+  nested: (o) => ({
+    id: o.id,
+    user: {
+      id: o.user?.id,
+      name: o.user?.name,
+      address: {
+        ...pick(o.user?.address, 'street', 'city', 'zip'),
+        geo: pick(o.user?.address?.geo, 'lat', 'lng'),
+      },
+    },
+    order: {
+      items: (o.order?.items || []).map((item: any) => ({
+        ...pick(item, 'sku', 'quantity', 'price'),
+        attributes: pick(item.attributes, 'color', 'size'),
+      })),
+      totals: pick(o.order?.totals, 'subtotal', 'tax', 'total'),
+    },
+    meta: {
+      tags: o.meta?.tags,
+      history: (o.meta?.history || []).map((event: any) => pick(event, 'timestamp', 'event')),
+    },
+  }),
+  numeric: (o) => ({ ...pick(o, 'id', 'values', 'counts'), stats: pick(o.stats, 'min', 'max', 'mean', 'stddev') }),
+  string: (o) => pick(o, 'id', 'title', 'body', 'author', 'url', 'tags'),
+};
+
+if (!shapes[shape]) {
+  console.error(`Invalid shape: ${shape}. Use 'items', 'flat', 'nested', 'numeric' or 'string'`);
+  process.exit(1);
+}
+if (!['typed', 'untyped', 'streaming'].includes(decodeMode)) {
+  console.error(`Invalid decode mode: ${decodeMode}. Use 'typed', 'untyped' or 'streaming'`);
+  process.exit(1);
+}
+
+const defaultFile = shape === 'items' ? 'xlarge_lines.json' : `shape_${shape}_lines.json`;
+const filePath = parseArgs.file || `test_data/${defaultFile}`;
+
+// Every technology splits on \n, drops a trailing \r and skips empty lines
+function* splitLines(text: string): Generator<string> {
+  for (let line of text.split('\n')) {
+    if (line.endsWith('\r')) {
+      line = line.slice(0, -1);
+    }
+    if (line.length > 0) {
+      yield line;
+    }
+  }
+}
+
+// parseLines decodes every line and returns how many there were. When hasher
+// is given the id of every parsed record is fed to it.
+function parseLines(lines: Iterable<string>, decode: Decoder, hasher: Bun.CryptoHasher | undefined, offset: number): number {
+  let count = 0;
   for (const line of lines) {
+    let record: any;
     try {
-      JSON.parse(line);
-      lineCount++;
+      record = decode(JSON.parse(line));
     } catch (err) {
-      throw new Error(`Error parsing JSON line ${lineCount + 1}: ${(err as Error).message}`);
+      throw new Error(`Error parsing JSON line ${offset + count + 1}: ${(err as Error).message}`);
+    }
+    if (hasher) {
+      hasher.update(`${record.id}\n`);
+    }
+    count++;
+  }
+  return count;
+}
+
+// readWhole reads the file into memory and splits it into lines first
+async function readWhole(path: string, decode: Decoder, hasher?: Bun.CryptoHasher): Promise<number> {
+  const text = await Bun.file(path).text();
+  return parseLines(splitLines(text), decode, hasher, 0);
+}
+
+// readStreaming parses complete lines as chunks arrive, without holding the
+// file in memory
+async function readStreaming(path: string, decode: Decoder, hasher?: Bun.CryptoHasher): Promise<number> {
+  const decoder = new TextDecoder();
+  let count = 0;
+  let rest = '';
+  for await (const chunk of Bun.file(path).stream()) {
+    const text = rest + decoder.decode(chunk, { stream: true });
+    const end = text.lastIndexOf('\n');
+    if (end === -1) {
+      rest = text;
+      continue;
     }
+    count += parseLines(splitLines(text.slice(0, end)), decode, hasher, count);
+    rest = text.slice(end + 1);
   }
-  
-  return lineCount;
+  rest += decoder.decode();
+  return count + parseLines(splitLines(rest), decode, hasher, count);
 }
 
 async function run() {
+  const decode: Decoder = decodeMode === 'typed' ? shapes[shape] : (record) => record;
+  const read = decodeMode === 'streaming' ? readStreaming : readWhole;
+
+  // The digest hashes the ids parsed in the first iteration
+  const hasher = new Bun.CryptoHasher('sha256');
   const startTime = process.hrtime.bigint();
   let totalLines = 0;
 
   for (let i = 0; i < iterations; i++) {
-    const lineCount = await readJsonLines(filePath);
+    const lineCount = await read(filePath, decode, i === 0 ? hasher : undefined);
     if (i === 0) {
       totalLines = lineCount;
     }
@@ -51,10 +142,13 @@ async function run() {
     totalTimeMs: totalTimeMs,
     operationsPerSecond: opsPerSecond,
     linesPerFile: totalLines,
+    decodeMode: decodeMode,
+    shape: shape,
+    digest: hasher.digest('hex'),
   }));
 }
 
 run().catch(err => {
   console.error(err);
   process.exit(1);
-}); 
\ No newline at end of file
+});
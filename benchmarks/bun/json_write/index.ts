@@ -10,40 +10,101 @@ const args = process.argv.slice(2).reduce((acc: Record<string, string>, arg: str
 const OUTPUT_PATH = args.output || 'test_data/temp_output.json';
 const ITERATIONS = parseInt(args.iterations || '1000', 10);
 const DATA_SIZE = parseInt(args.size || '100', 10);
+const DECODE_MODE = args['decode-mode'] || 'typed';
+
+if (!['typed', 'untyped', 'streaming'].includes(DECODE_MODE)) {
+  console.error(`Invalid decode mode: ${DECODE_MODE}. Use 'typed', 'untyped' or 'streaming'`);
+  process.exit(1);
+}
+
+// Hex SHA-256 of a file, compared across technologies by the runner
+async function fileDigest(path: string): Promise<string> {
+  const hasher = new Bun.CryptoHasher('sha256');
+  hasher.update(await Bun.file(path).arrayBuffer());
+  return hasher.digest('hex');
+}
+
+// TestItem mirrors the Go struct, so typed items serialize in field order
+class TestItem {
+  id: number;
+  name: string;
+  tags: string[];
+  metadata: Record<string, unknown>;
+
+  constructor(id: number) {
+    this.id = id;
+    this.name = `Item_${id}`;
+    this.tags = ['tag1', 'tag2', 'tag3'];
+    // The creation time is fixed and the keys are sorted like Go's map
+    // encoding so every technology writes an identical document
+    this.metadata = {
+      active: true,
+      created: 1700000000,
+      version: '1.0',
+    };
+  }
+}
+
+// writeStreaming encodes one item at a time through a write stream. The
+// output is byte-for-byte identical to writing the whole document at once.
+async function writeStreaming(outputPath: string, items: TestItem[]): Promise<void> {
+  const writer = Bun.file(outputPath).writer();
+  if (items.length === 0) {
+    writer.write('[]');
+  } else {
+    writer.write('[\n  ');
+    items.forEach((item, i) => {
+      if (i > 0) {
+        writer.write(',\n  ');
+      }
+      writer.write(JSON.stringify(item, null, 2).replace(/\n/g, '\n  '));
+    });
+    writer.write('\n]');
+  }
+  await writer.end();
+}
 
 async function run() {
-  // Create test data
-  const testData = [];
+  const testData: TestItem[] = [];
   for (let i = 0; i < DATA_SIZE; i++) {
-    testData.push({
-      id: i,
-      name: `Item_${i}`,
-      tags: ['tag1', 'tag2', 'tag3'],
-      metadata: {
-        created: Date.now(),
-        version: '1.0',
-        active: true,
-      },
-    });
+    testData.push(new TestItem(i));
   }
 
+  // The untyped mode writes the same items as plain objects, with the keys
+  // sorted like Go's map encoding
+  const untypedData = testData.map((item) => ({
+    id: item.id,
+    metadata: item.metadata,
+    name: item.name,
+    tags: item.tags,
+  }));
+
   const startTime = process.hrtime.bigint();
 
   // Serialize and write JSON multiple times
   for (let i = 0; i < ITERATIONS; i++) {
-    const jsonData = JSON.stringify(testData, null, 2);
-    await Bun.write(OUTPUT_PATH, jsonData);
+    if (DECODE_MODE === 'streaming') {
+      await writeStreaming(OUTPUT_PATH, testData);
+    } else {
+      const jsonData = JSON.stringify(DECODE_MODE === 'typed' ? testData : untypedData, null, 2);
+      await Bun.write(OUTPUT_PATH, jsonData);
+    }
   }
 
   const endTime = process.hrtime.bigint();
   const totalTimeMs = Number(endTime - startTime) / 1_000_000;
   const opsPerSecond = (ITERATIONS / totalTimeMs) * 1000;
 
+  // Read the output back so the digest covers what actually reached the file
+  const digest = await fileDigest(OUTPUT_PATH);
+
   // Output JSON result to stdout
   console.log(JSON.stringify({
     operations: ITERATIONS,
     totalTimeMs: totalTimeMs,
     operationsPerSecond: opsPerSecond,
+    decodeMode: DECODE_MODE,
+    digest: digest,
   }));
 }
 
@@ -12,6 +12,12 @@ const parseArgs = process.argv.slice(2).reduce((acc: Record<string, string>, arg
 const outputPath = parseArgs.output || 'test_data/temp_output_lines.json';
 const iterations = parseInt(parseArgs.iterations || '10', 10);
 const lineCount = parseInt(parseArgs.lines || '1000000', 10);
+const decodeMode = parseArgs['decode-mode'] || 'typed';
+
+if (!['typed', 'untyped', 'streaming'].includes(decodeMode)) {
+  console.error(`Invalid decode mode: ${decodeMode}. Use 'typed', 'untyped' or 'streaming'`);
+  process.exit(1);
+}
 
 const sampleCategories = ["test", "benchmark", "data", "sample", "mock"];
 const samplePriorities = ["low", "medium", "high", "critical"];
@@ -23,6 +29,10 @@ const sampleTags = [
   ["json", "test"]
 ];
 
+// Timestamps are derived from a fixed base time so every technology writes
+// identical lines
+const BASE_TIME = 1700000000 * 1000;
+
 interface SampleItem {
   id: number;
   name: string;
@@ -35,26 +45,66 @@ interface SampleItem {
   active: boolean;
 }
 
+// Hex SHA-256 of a file, compared across technologies by the runner
+async function fileDigest(path: string): Promise<string> {
+  const hasher = new Bun.CryptoHasher('sha256');
+  hasher.update(await Bun.file(path).arrayBuffer());
+  return hasher.digest('hex');
+}
+
+// makeItem builds line j with its fields in the order of the Go struct
+function makeItem(j: number): SampleItem {
+  return {
+    id: j + 1,
+    name: `Item_${String(j + 1).padStart(7, '0')}`,
+    description: `Sample item ${j + 1} for benchmarking JSON line operations`,
+    category: sampleCategories[j % sampleCategories.length],
+    priority: samplePriorities[j % samplePriorities.length],
+    tags: sampleTags[j % sampleTags.length],
+    timestamp: new Date(BASE_TIME + j * 1000).toISOString(),
+    value: j * 100,
+    active: j % 3 === 0
+  };
+}
+
+// untypedItem copies an item into a plain object with its keys sorted like
+// Go's map encoding
+function untypedItem(item: SampleItem): Record<string, unknown> {
+  return {
+    active: item.active,
+    category: item.category,
+    description: item.description,
+    id: item.id,
+    name: item.name,
+    priority: item.priority,
+    tags: item.tags,
+    timestamp: item.timestamp,
+    value: item.value,
+  };
+}
+
+// writeStreaming writes the lines one at a time through a file writer
+async function writeStreaming(path: string): Promise<void> {
+  const writer = Bun.file(path).writer();
+  for (let j = 0; j < lineCount; j++) {
+    writer.write(JSON.stringify(makeItem(j)) + '\n');
+  }
+  await writer.end();
+}
+
 async function run() {
   const startTime = process.hrtime.bigint();
 
   for (let i = 0; i < iterations; i++) {
+    if (decodeMode === 'streaming') {
+      await writeStreaming(outputPath);
+      continue;
+    }
+
     let content = '';
-    
     for (let j = 0; j < lineCount; j++) {
-      const item: SampleItem = {
-        id: j + 1,
-        name: `Item_${String(j + 1).padStart(7, '0')}`,
-        description: `Sample item ${j + 1} for benchmarking JSON line operations`,
-        category: sampleCategories[j % sampleCategories.length],
-        priority: samplePriorities[j % samplePriorities.length],
-        tags: sampleTags[j % sampleTags.length],
-        timestamp: new Date(Date.now() + j * 1000).toISOString(),
-        value: j * 100,
-        active: j % 3 === 0
-      };
-      
-      content += JSON.stringify(item) + '\n';
+      const item = makeItem(j);
+      content += JSON.stringify(decodeMode === 'typed' ? item : untypedItem(item)) + '\n';
     }
 
     await Bun.write(outputPath, content);
@@ -64,6 +114,10 @@ async function run() {
   const totalTimeMs = Number(endTime - startTime) / 1_000_000;
   const opsPerSecond = (iterations / totalTimeMs) * 1000;
 
+  // Read the output back before cleaning up so the digest covers what
+  // actually reached the file
+  const digest = await fileDigest(outputPath);
+
   // Clean up temp file
   try {
     await Bun.write(outputPath, '');
@@ -77,6 +131,8 @@ async function run() {
     totalTimeMs: totalTimeMs,
     operationsPerSecond: opsPerSecond,
     linesPerIteration: lineCount,
+    decodeMode: decodeMode,
+    digest: digest,
   }));
 }
 
@@ -9,6 +9,8 @@ import (
 	"net/http"
 	"strconv"
 	"time"
+
+	"performance-benchmark-suite/benchmarks/go/internal/profile"
 )
 
 type ColdStartResult struct {
@@ -26,6 +28,7 @@ func main() {
 	flag.IntVar(&iterations, "iterations", 10, "Number of cold start measurements")
 	flag.IntVar(&timeout, "timeout", 10000, "Timeout in milliseconds for each cold start attempt")
 	flag.Parse()
+	defer profile.Start()()
 
 	portInt, err := strconv.Atoi(port)
 	if err != nil {
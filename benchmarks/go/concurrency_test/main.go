@@ -2,12 +2,15 @@ package main
 
 import (
 	"crypto/sha256"
+	"encoding/hex"
 	"flag"
 	"fmt"
 	"os"
 	"runtime"
 	"sync"
 	"time"
+
+	"performance-benchmark-suite/benchmarks/go/internal/profile"
 )
 
 func main() {
@@ -17,6 +20,11 @@ func main() {
 	flag.StringVar(&mode, "mode", "single", "Mode: single or multi")
 	flag.IntVar(&workload, "workload", 1000000, "Number of hash operations to perform")
 	flag.Parse()
+	defer profile.Start()()
+
+	// digest is the XOR of every hash, which does not depend on the order the
+	// work was split or finished in
+	var digest [sha256.Size]byte
 
 	startTime := time.Now()
 
@@ -25,13 +33,14 @@ func main() {
 		for i := 0; i < workload; i++ {
 			data := fmt.Sprintf("data_%d", i)
 			hash := sha256.Sum256([]byte(data))
-			_ = hash // Prevent optimization
+			xorInto(&digest, hash)
 		}
 	} else if mode == "multi" {
 		// Multi-threaded execution
 		numCPU := runtime.NumCPU()
 		workPerCPU := workload / numCPU
 
+		partials := make([][sha256.Size]byte, numCPU)
 		var wg sync.WaitGroup
 		for cpu := 0; cpu < numCPU; cpu++ {
 			wg.Add(1)
@@ -46,11 +55,15 @@ func main() {
 				for i := start; i < end; i++ {
 					data := fmt.Sprintf("data_%d", i)
 					hash := sha256.Sum256([]byte(data))
-					_ = hash // Prevent optimization
+					xorInto(&partials[cpuID], hash)
 				}
 			}(cpu)
 		}
 		wg.Wait()
+
+		for _, partial := range partials {
+			xorInto(&digest, partial)
+		}
 	} else {
 		fmt.Fprintf(os.Stderr, "Invalid mode: %s. Use 'single' or 'multi'\n", mode)
 		os.Exit(1)
@@ -62,6 +75,12 @@ func main() {
 	opsPerSecond := float64(workload) / (totalTimeMs / 1000.0)
 
 	// Output JSON result to stdout
-	fmt.Printf(`{"operations":%d,"totalTimeMs":%.2f,"operationsPerSecond":%.2f,"mode":"%s"}`,
-		workload, totalTimeMs, opsPerSecond, mode)
+	fmt.Printf(`{"operations":%d,"totalTimeMs":%.2f,"operationsPerSecond":%.2f,"mode":"%s","digest":"%s"}`,
+		workload, totalTimeMs, opsPerSecond, mode, hex.EncodeToString(digest[:]))
+}
+
+func xorInto(dst *[sha256.Size]byte, src [sha256.Size]byte) {
+	for i := range dst {
+		dst[i] ^= src[i]
+	}
 }
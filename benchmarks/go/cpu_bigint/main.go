@@ -0,0 +1,92 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"performance-benchmark-suite/benchmarks/go/internal/profile"
+)
+
+// bigIntWork computes n! and the (n+1)-th Fibonacci number with arbitrary
+// precision integers, then reduces the factorial modulo the Fibonacci number.
+// It returns the bit length of the remainder so the work cannot be skipped.
+func bigIntWork(n int) int {
+	factorial := big.NewInt(1)
+	for i := 2; i <= n; i++ {
+		factorial.Mul(factorial, big.NewInt(int64(i)))
+	}
+
+	a, b := big.NewInt(0), big.NewInt(1)
+	for i := 0; i < n; i++ {
+		a.Add(a, b)
+		a, b = b, a
+	}
+
+	remainder := new(big.Int).Mod(factorial, b)
+	return remainder.BitLen()
+}
+
+func runRange(count, n int) int {
+	bits := 0
+	for i := 0; i < count; i++ {
+		bits = bigIntWork(n)
+	}
+	return bits
+}
+
+func main() {
+	var mode string
+	var workload int
+	var n int
+
+	flag.StringVar(&mode, "mode", "single", "Mode: single or multi")
+	flag.IntVar(&workload, "workload", 1000, "Number of big-integer computations")
+	flag.IntVar(&n, "n", 2000, "Size of each computation (n! and fib(n))")
+	flag.Parse()
+	defer profile.Start()()
+
+	startTime := time.Now()
+	bits := 0
+
+	if mode == "single" {
+		// Single-threaded execution
+		bits = runRange(workload, n)
+	} else if mode == "multi" {
+		// Multi-threaded execution
+		numCPU := runtime.NumCPU()
+		workPerCPU := workload / numCPU
+		results := make([]int, numCPU)
+
+		var wg sync.WaitGroup
+		for cpu := 0; cpu < numCPU; cpu++ {
+			wg.Add(1)
+			go func(cpuID int) {
+				defer wg.Done()
+				count := workPerCPU
+				if cpuID == numCPU-1 {
+					count = workload - workPerCPU*(numCPU-1) // Last CPU gets remaining work
+				}
+				results[cpuID] = runRange(count, n)
+			}(cpu)
+		}
+		wg.Wait()
+		bits = results[numCPU-1]
+	} else {
+		fmt.Fprintf(os.Stderr, "Invalid mode: %s. Use 'single' or 'multi'\n", mode)
+		os.Exit(1)
+	}
+
+	endTime := time.Now()
+	totalTime := endTime.Sub(startTime)
+	totalTimeMs := float64(totalTime.Microseconds()) / 1000.0
+	opsPerSecond := float64(workload) / (totalTimeMs / 1000.0)
+
+	// Output JSON result to stdout
+	fmt.Printf(`{"operations":%d,"totalTimeMs":%.2f,"operationsPerSecond":%.2f,"mode":"%s","resultBits":%d}`,
+		workload, totalTimeMs, opsPerSecond, mode, bits)
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+
+	"performance-benchmark-suite/benchmarks/go/internal/profile"
+)
+
+// generatePayload builds compressible text similar to log or JSON traffic
+func generatePayload(size int) []byte {
+	words := []string{"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "do"}
+	var buf bytes.Buffer
+	for i := 0; buf.Len() < size; i++ {
+		buf.WriteString(words[i%len(words)])
+		buf.WriteByte(' ')
+		buf.WriteString(strconv.Itoa(i * 7919 % 100000))
+		if i%12 == 11 {
+			buf.WriteByte('\n')
+		} else {
+			buf.WriteByte(' ')
+		}
+	}
+	return buf.Bytes()[:size]
+}
+
+// roundTrip compresses and decompresses the payload count times and returns
+// the compressed size of the last round trip
+func roundTrip(payload []byte, count int) (int, error) {
+	var compressed bytes.Buffer
+	decompressed := make([]byte, 0, len(payload))
+	compressedSize := 0
+
+	for i := 0; i < count; i++ {
+		compressed.Reset()
+		writer := gzip.NewWriter(&compressed)
+		if _, err := writer.Write(payload); err != nil {
+			return 0, err
+		}
+		if err := writer.Close(); err != nil {
+			return 0, err
+		}
+		compressedSize = compressed.Len()
+
+		reader, err := gzip.NewReader(&compressed)
+		if err != nil {
+			return 0, err
+		}
+		out := bytes.NewBuffer(decompressed[:0])
+		if _, err := io.Copy(out, reader); err != nil {
+			return 0, err
+		}
+		reader.Close()
+		if out.Len() != len(payload) {
+			return 0, fmt.Errorf("decompressed %d bytes, expected %d", out.Len(), len(payload))
+		}
+	}
+	return compressedSize, nil
+}
+
+func main() {
+	var mode string
+	var workload int
+	var size int
+
+	flag.StringVar(&mode, "mode", "single", "Mode: single or multi")
+	flag.IntVar(&workload, "workload", 200, "Number of compress/decompress round trips")
+	flag.IntVar(&size, "size", 65536, "Size in bytes of the payload")
+	flag.Parse()
+	defer profile.Start()()
+
+	payload := generatePayload(size)
+
+	startTime := time.Now()
+	compressedSize := 0
+
+	if mode == "single" {
+		// Single-threaded execution
+		n, err := roundTrip(payload, workload)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error during gzip round trip: %v\n", err)
+			os.Exit(1)
+		}
+		compressedSize = n
+	} else if mode == "multi" {
+		// Multi-threaded execution
+		numCPU := runtime.NumCPU()
+		workPerCPU := workload / numCPU
+		sizes := make([]int, numCPU)
+		errs := make([]error, numCPU)
+
+		var wg sync.WaitGroup
+		for cpu := 0; cpu < numCPU; cpu++ {
+			wg.Add(1)
+			go func(cpuID int) {
+				defer wg.Done()
+				count := workPerCPU
+				if cpuID == numCPU-1 {
+					count = workload - workPerCPU*(numCPU-1) // Last CPU gets remaining work
+				}
+				sizes[cpuID], errs[cpuID] = roundTrip(payload, count)
+			}(cpu)
+		}
+		wg.Wait()
+
+		for cpu, err := range errs {
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error during gzip round trip: %v\n", err)
+				os.Exit(1)
+			}
+			if sizes[cpu] > 0 {
+				compressedSize = sizes[cpu]
+			}
+		}
+	} else {
+		fmt.Fprintf(os.Stderr, "Invalid mode: %s. Use 'single' or 'multi'\n", mode)
+		os.Exit(1)
+	}
+
+	endTime := time.Now()
+	totalTime := endTime.Sub(startTime)
+	totalTimeMs := float64(totalTime.Microseconds()) / 1000.0
+	opsPerSecond := float64(workload) / (totalTimeMs / 1000.0)
+
+	// Output JSON result to stdout
+	fmt.Printf(`{"operations":%d,"totalTimeMs":%.2f,"operationsPerSecond":%.2f,"mode":"%s","compressedBytes":%d}`,
+		workload, totalTimeMs, opsPerSecond, mode, compressedSize)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+
+	"performance-benchmark-suite/benchmarks/go/internal/profile"
+)
+
+// mapWorkload inserts, updates, looks up and deletes string keys drawn from a
+// fixed key space, the mix seen in caches and aggregation code. It returns the
+// number of entries left in the map and the number of successful lookups.
+func mapWorkload(keys []string, start, end int) (int, int) {
+	counts := make(map[string]int)
+	hits := 0
+	for i := start; i < end; i++ {
+		key := keys[(i*7919)%len(keys)]
+		counts[key]++
+		if _, ok := counts[keys[i%len(keys)]]; ok {
+			hits++
+		}
+		if i%3 == 0 {
+			delete(counts, keys[(i*31)%len(keys)])
+		}
+	}
+	return len(counts), hits
+}
+
+func main() {
+	var mode string
+	var workload int
+	var keySpace int
+
+	flag.StringVar(&mode, "mode", "single", "Mode: single or multi")
+	flag.IntVar(&workload, "workload", 2000000, "Number of map operations to perform")
+	flag.IntVar(&keySpace, "keys", 100000, "Number of distinct keys")
+	flag.Parse()
+	defer profile.Start()()
+
+	if keySpace < 1 {
+		fmt.Fprintf(os.Stderr, "Invalid key space: %d\n", keySpace)
+		os.Exit(1)
+	}
+
+	// Build keys up front so key formatting is not part of the measurement
+	keys := make([]string, keySpace)
+	for i := range keys {
+		keys[i] = "key_" + strconv.Itoa(i)
+	}
+
+	startTime := time.Now()
+	entries, hits := 0, 0
+
+	if mode == "single" {
+		// Single-threaded execution
+		entries, hits = mapWorkload(keys, 0, workload)
+	} else if mode == "multi" {
+		// Multi-threaded execution, one map per worker
+		numCPU := runtime.NumCPU()
+		workPerCPU := workload / numCPU
+		entryCounts := make([]int, numCPU)
+		hitCounts := make([]int, numCPU)
+
+		var wg sync.WaitGroup
+		for cpu := 0; cpu < numCPU; cpu++ {
+			wg.Add(1)
+			go func(cpuID int) {
+				defer wg.Done()
+				start := cpuID * workPerCPU
+				end := start + workPerCPU
+				if cpuID == numCPU-1 {
+					end = workload // Last CPU gets remaining work
+				}
+				entryCounts[cpuID], hitCounts[cpuID] = mapWorkload(keys, start, end)
+			}(cpu)
+		}
+		wg.Wait()
+
+		for cpu := range entryCounts {
+			entries += entryCounts[cpu]
+			hits += hitCounts[cpu]
+		}
+	} else {
+		fmt.Fprintf(os.Stderr, "Invalid mode: %s. Use 'single' or 'multi'\n", mode)
+		os.Exit(1)
+	}
+
+	endTime := time.Now()
+	totalTime := endTime.Sub(startTime)
+	totalTimeMs := float64(totalTime.Microseconds()) / 1000.0
+	opsPerSecond := float64(workload) / (totalTimeMs / 1000.0)
+
+	// Output JSON result to stdout
+	fmt.Printf(`{"operations":%d,"totalTimeMs":%.2f,"operationsPerSecond":%.2f,"mode":"%s","entries":%d,"hits":%d}`,
+		workload, totalTimeMs, opsPerSecond, mode, entries, hits)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+
+	"performance-benchmark-suite/benchmarks/go/internal/profile"
+)
+
+// Log lines in the shape of a typical access log; one in four is malformed
+// so both the matching and the failing paths are exercised.
+var logPattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})T(\d{2}:\d{2}:\d{2})Z \[(INFO|WARN|ERROR)\] (GET|POST|PUT|DELETE) (/[a-z0-9/_-]*) status=(\d{3}) duration=(\d+)ms user=([a-z0-9._]+@[a-z0-9.-]+\.[a-z]{2,})$`)
+
+var levels = []string{"INFO", "WARN", "ERROR"}
+var methods = []string{"GET", "POST", "PUT", "DELETE"}
+
+func generateLines(count int) []string {
+	lines := make([]string, count)
+	for i := 0; i < count; i++ {
+		line := "2024-01-" + fmt.Sprintf("%02d", i%28+1) + "T12:" + fmt.Sprintf("%02d:%02d", i%60, (i*7)%60) + "Z" +
+			" [" + levels[i%len(levels)] + "] " + methods[i%len(methods)] +
+			" /api/v1/items/" + strconv.Itoa(i) +
+			" status=" + strconv.Itoa(200+(i%5)*100) +
+			" duration=" + strconv.Itoa(i%997) + "ms"
+		if i%4 == 3 {
+			line += " user=malformed"
+		} else {
+			line += " user=user" + strconv.Itoa(i) + "@example.com"
+		}
+		lines[i] = line
+	}
+	return lines
+}
+
+func matchRange(lines []string, start, end int) int {
+	matches := 0
+	for i := start; i < end; i++ {
+		if groups := logPattern.FindStringSubmatch(lines[i%len(lines)]); groups != nil {
+			matches++
+		}
+	}
+	return matches
+}
+
+func main() {
+	var mode string
+	var workload int
+
+	flag.StringVar(&mode, "mode", "single", "Mode: single or multi")
+	flag.IntVar(&workload, "workload", 200000, "Number of lines to match")
+	flag.Parse()
+	defer profile.Start()()
+
+	lines := generateLines(1024)
+
+	startTime := time.Now()
+	matches := 0
+
+	if mode == "single" {
+		// Single-threaded execution
+		matches = matchRange(lines, 0, workload)
+	} else if mode == "multi" {
+		// Multi-threaded execution
+		numCPU := runtime.NumCPU()
+		workPerCPU := workload / numCPU
+		results := make([]int, numCPU)
+
+		var wg sync.WaitGroup
+		for cpu := 0; cpu < numCPU; cpu++ {
+			wg.Add(1)
+			go func(cpuID int) {
+				defer wg.Done()
+				start := cpuID * workPerCPU
+				end := start + workPerCPU
+				if cpuID == numCPU-1 {
+					end = workload // Last CPU gets remaining work
+				}
+				results[cpuID] = matchRange(lines, start, end)
+			}(cpu)
+		}
+		wg.Wait()
+
+		for _, m := range results {
+			matches += m
+		}
+	} else {
+		fmt.Fprintf(os.Stderr, "Invalid mode: %s. Use 'single' or 'multi'\n", mode)
+		os.Exit(1)
+	}
+
+	endTime := time.Now()
+	totalTime := endTime.Sub(startTime)
+	totalTimeMs := float64(totalTime.Microseconds()) / 1000.0
+	opsPerSecond := float64(workload) / (totalTimeMs / 1000.0)
+
+	// Output JSON result to stdout
+	fmt.Printf(`{"operations":%d,"totalTimeMs":%.2f,"operationsPerSecond":%.2f,"mode":"%s","matches":%d}`,
+		workload, totalTimeMs, opsPerSecond, mode, matches)
+}
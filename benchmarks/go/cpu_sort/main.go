@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"performance-benchmark-suite/benchmarks/go/internal/profile"
+)
+
+// generateArray fills an array with xorshift32 values so every runtime can
+// reproduce the same input from the same seed
+func generateArray(size int, seed uint32) []int {
+	values := make([]int, size)
+	state := seed
+	for i := range values {
+		state ^= state << 13
+		state ^= state >> 17
+		state ^= state << 5
+		values[i] = int(state % 1000000)
+	}
+	return values
+}
+
+// sortCopies sorts a fresh copy of the input count times and returns the
+// median of the last sorted copy
+func sortCopies(input []int, count int) int {
+	work := make([]int, len(input))
+	median := 0
+	for i := 0; i < count; i++ {
+		copy(work, input)
+		sort.Ints(work)
+		median = work[len(work)/2]
+	}
+	return median
+}
+
+func main() {
+	var mode string
+	var workload int
+	var size int
+
+	flag.StringVar(&mode, "mode", "single", "Mode: single or multi")
+	flag.IntVar(&workload, "workload", 50, "Number of arrays to sort")
+	flag.IntVar(&size, "size", 100000, "Number of elements in each array")
+	flag.Parse()
+	defer profile.Start()()
+
+	if size < 1 {
+		fmt.Fprintf(os.Stderr, "Invalid size: %d\n", size)
+		os.Exit(1)
+	}
+
+	input := generateArray(size, 2463534242)
+
+	startTime := time.Now()
+	median := 0
+
+	if mode == "single" {
+		// Single-threaded execution
+		median = sortCopies(input, workload)
+	} else if mode == "multi" {
+		// Multi-threaded execution
+		numCPU := runtime.NumCPU()
+		workPerCPU := workload / numCPU
+		medians := make([]int, numCPU)
+
+		var wg sync.WaitGroup
+		for cpu := 0; cpu < numCPU; cpu++ {
+			wg.Add(1)
+			go func(cpuID int) {
+				defer wg.Done()
+				count := workPerCPU
+				if cpuID == numCPU-1 {
+					count = workload - workPerCPU*(numCPU-1) // Last CPU gets remaining work
+				}
+				medians[cpuID] = sortCopies(input, count)
+			}(cpu)
+		}
+		wg.Wait()
+		median = medians[numCPU-1]
+	} else {
+		fmt.Fprintf(os.Stderr, "Invalid mode: %s. Use 'single' or 'multi'\n", mode)
+		os.Exit(1)
+	}
+
+	endTime := time.Now()
+	totalTime := endTime.Sub(startTime)
+	totalTimeMs := float64(totalTime.Microseconds()) / 1000.0
+	opsPerSecond := float64(workload) / (totalTimeMs / 1000.0)
+
+	// Output JSON result to stdout
+	fmt.Printf(`{"operations":%d,"totalTimeMs":%.2f,"operationsPerSecond":%.2f,"mode":"%s","median":%d}`,
+		workload, totalTimeMs, opsPerSecond, mode, median)
+}
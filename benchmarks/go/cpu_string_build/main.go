@@ -0,0 +1,94 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"performance-benchmark-suite/benchmarks/go/internal/profile"
+)
+
+// buildStrings assembles count CSV-like records of the given number of fields
+// with a strings.Builder and returns the total number of bytes produced
+func buildStrings(start, end, fields int) int {
+	var builder strings.Builder
+	var scratch []byte
+	total := 0
+	for i := start; i < end; i++ {
+		builder.Reset()
+		for f := 0; f < fields; f++ {
+			if f > 0 {
+				builder.WriteByte(',')
+			}
+			builder.WriteString("field")
+			scratch = strconv.AppendInt(scratch[:0], int64(f), 10)
+			builder.Write(scratch)
+			builder.WriteByte('=')
+			scratch = strconv.AppendInt(scratch[:0], int64(i*fields+f), 10)
+			builder.Write(scratch)
+		}
+		total += len(builder.String())
+	}
+	return total
+}
+
+func main() {
+	var mode string
+	var workload int
+	var fields int
+
+	flag.StringVar(&mode, "mode", "single", "Mode: single or multi")
+	flag.IntVar(&workload, "workload", 100000, "Number of strings to build")
+	flag.IntVar(&fields, "fields", 50, "Number of fields appended to each string")
+	flag.Parse()
+	defer profile.Start()()
+
+	startTime := time.Now()
+	totalBytes := 0
+
+	if mode == "single" {
+		// Single-threaded execution
+		totalBytes = buildStrings(0, workload, fields)
+	} else if mode == "multi" {
+		// Multi-threaded execution
+		numCPU := runtime.NumCPU()
+		workPerCPU := workload / numCPU
+		results := make([]int, numCPU)
+
+		var wg sync.WaitGroup
+		for cpu := 0; cpu < numCPU; cpu++ {
+			wg.Add(1)
+			go func(cpuID int) {
+				defer wg.Done()
+				start := cpuID * workPerCPU
+				end := start + workPerCPU
+				if cpuID == numCPU-1 {
+					end = workload // Last CPU gets remaining work
+				}
+				results[cpuID] = buildStrings(start, end, fields)
+			}(cpu)
+		}
+		wg.Wait()
+
+		for _, n := range results {
+			totalBytes += n
+		}
+	} else {
+		fmt.Fprintf(os.Stderr, "Invalid mode: %s. Use 'single' or 'multi'\n", mode)
+		os.Exit(1)
+	}
+
+	endTime := time.Now()
+	totalTime := endTime.Sub(startTime)
+	totalTimeMs := float64(totalTime.Microseconds()) / 1000.0
+	opsPerSecond := float64(workload) / (totalTimeMs / 1000.0)
+
+	// Output JSON result to stdout
+	fmt.Printf(`{"operations":%d,"totalTimeMs":%.2f,"operationsPerSecond":%.2f,"mode":"%s","totalBytes":%d}`,
+		workload, totalTimeMs, opsPerSecond, mode, totalBytes)
+}
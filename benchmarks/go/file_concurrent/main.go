@@ -0,0 +1,227 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"performance-benchmark-suite/benchmarks/go/internal/profile"
+)
+
+// worker owns one file and performs its operations on it sequentially;
+// concurrency comes from running the workers side by side
+type worker struct {
+	id        int
+	file      *os.File
+	latencies []float64
+	err       error
+}
+
+func main() {
+	var outputPath string
+	var workers int
+	var operations int
+	var blockSize int
+	var fileSize int
+	var pattern string
+	var ioMode string
+
+	flag.StringVar(&outputPath, "output", "", "Path prefix for the worker files")
+	flag.IntVar(&workers, "workers", 4, "Number of concurrent workers, each with its own file")
+	flag.IntVar(&operations, "operations", 4096, "Number of operations per worker")
+	flag.IntVar(&blockSize, "block-size", 4096, "Size of each read or write in bytes")
+	flag.IntVar(&fileSize, "file-size", 16*1024*1024, "Size of each worker file in bytes")
+	flag.StringVar(&pattern, "pattern", "sequential", "Access pattern: sequential or random")
+	flag.StringVar(&ioMode, "io", "mixed", "Operations: read, write or mixed")
+	flag.Parse()
+	defer profile.Start()()
+
+	if pattern != "sequential" && pattern != "random" {
+		fmt.Fprintf(os.Stderr, "Invalid pattern: %s. Use 'sequential' or 'random'\n", pattern)
+		os.Exit(1)
+	}
+	if ioMode != "read" && ioMode != "write" && ioMode != "mixed" {
+		fmt.Fprintf(os.Stderr, "Invalid io mode: %s. Use 'read', 'write' or 'mixed'\n", ioMode)
+		os.Exit(1)
+	}
+	if workers < 1 || operations < 1 || blockSize < 1 || fileSize < blockSize {
+		fmt.Fprintf(os.Stderr, "workers, operations and block-size must be positive and file-size at least block-size\n")
+		os.Exit(1)
+	}
+	blocks := fileSize / blockSize
+
+	// Default output path if not provided
+	if outputPath == "" {
+		outputPath = filepath.Join("test_data", "temp_concurrent.dat")
+	}
+
+	// Lay out every worker file before timing starts
+	pool := make([]*worker, workers)
+	for id := range pool {
+		file, err := createWorkerFile(workerPath(outputPath, id), id, blocks, blockSize)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error preparing worker file: %v\n", err)
+			os.Exit(1)
+		}
+		pool[id] = &worker{id: id, file: file, latencies: make([]float64, 0, operations)}
+	}
+
+	startTime := time.Now()
+
+	var wg sync.WaitGroup
+	for _, w := range pool {
+		wg.Add(1)
+		go func(w *worker) {
+			defer wg.Done()
+			w.err = w.run(operations, blocks, blockSize, pattern, ioMode)
+		}(w)
+	}
+	wg.Wait()
+
+	totalTime := time.Since(startTime)
+
+	for _, w := range pool {
+		if w.err != nil {
+			fmt.Fprintf(os.Stderr, "Error in worker %d: %v\n", w.id, w.err)
+			os.Exit(1)
+		}
+		w.file.Close()
+	}
+
+	// The digest covers the final contents of every worker file in order
+	digest := sha256.New()
+	for id := range pool {
+		if err := hashFile(digest, workerPath(outputPath, id)); err != nil {
+			fmt.Fprintf(os.Stderr, "Error hashing worker file: %v\n", err)
+			os.Exit(1)
+		}
+		os.Remove(workerPath(outputPath, id))
+	}
+
+	var latencies []float64
+	for _, w := range pool {
+		latencies = append(latencies, w.latencies...)
+	}
+	sort.Float64s(latencies)
+	var latencyTotal float64
+	for _, l := range latencies {
+		latencyTotal += l
+	}
+
+	totalOps := workers * operations
+	totalTimeMs := float64(totalTime.Nanoseconds()) / 1e6
+	iops := float64(totalOps) / (totalTimeMs / 1000)
+	mbPerSecond := float64(totalOps) * float64(blockSize) / 1024 / 1024 / (totalTimeMs / 1000)
+
+	// Output JSON result to stdout
+	fmt.Printf(`{"operations":%d,"totalTimeMs":%.2f,"operationsPerSecond":%.2f,"iops":%.2f,"mbPerSecond":%.2f,"latencyAvgMs":%.4f,"latencyP50Ms":%.4f,"latencyP90Ms":%.4f,"latencyP95Ms":%.4f,"latencyP99Ms":%.4f,"workers":%d,"blockSize":%d,"pattern":"%s","io":"%s","digest":"%s"}`,
+		totalOps, totalTimeMs, iops, iops, mbPerSecond,
+		latencyTotal/float64(len(latencies)), percentile(latencies, 50), percentile(latencies, 90),
+		percentile(latencies, 95), percentile(latencies, 99),
+		workers, blockSize, pattern, ioMode, hex.EncodeToString(digest.Sum(nil)))
+}
+
+func (w *worker) run(operations, blocks, blockSize int, pattern, ioMode string) error {
+	buf := make([]byte, blockSize)
+	rng := newXorshift(w.id)
+
+	for i := 0; i < operations; i++ {
+		block := i % blocks
+		if pattern == "random" {
+			block = int(rng.next() % uint32(blocks))
+		}
+		offset := int64(block) * int64(blockSize)
+
+		write := ioMode == "write" || (ioMode == "mixed" && i%2 == 1)
+		if write {
+			fill(buf, byte(w.id*31+i))
+		}
+
+		start := time.Now()
+		var err error
+		if write {
+			_, err = w.file.WriteAt(buf, offset)
+		} else {
+			_, err = w.file.ReadAt(buf, offset)
+		}
+		w.latencies = append(w.latencies, float64(time.Since(start).Nanoseconds())/1e6)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createWorkerFile writes a file of blocks where block k holds the byte k*7+id
+func createWorkerFile(path string, id, blocks, blockSize int) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, blockSize)
+	for k := 0; k < blocks; k++ {
+		fill(buf, byte(k*7+id))
+		if _, err := file.Write(buf); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+	return file, nil
+}
+
+func workerPath(outputPath string, id int) string {
+	return fmt.Sprintf("%s.%d", outputPath, id)
+}
+
+func fill(buf []byte, value byte) {
+	for i := range buf {
+		buf[i] = value
+	}
+}
+
+func hashFile(w io.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(w, file)
+	return err
+}
+
+// xorshift is xorshift32, simple enough to reproduce exactly in JavaScript so
+// every runtime visits the same random offsets
+type xorshift struct {
+	state uint32
+}
+
+func newXorshift(id int) *xorshift {
+	return &xorshift{state: uint32(id+1) * 2654435761}
+}
+
+func (x *xorshift) next() uint32 {
+	x.state ^= x.state << 13
+	x.state ^= x.state >> 17
+	x.state ^= x.state << 5
+	return x.state
+}
+
+// percentile returns the nearest-rank percentile p of a sorted slice
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
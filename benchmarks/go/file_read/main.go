@@ -1,21 +1,35 @@
 package main
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"flag"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"time"
+
+	"performance-benchmark-suite/benchmarks/go/internal/fileio"
+	"performance-benchmark-suite/benchmarks/go/internal/profile"
 )
 
 func main() {
 	var filePath string
 	var iterations int
+	var opts fileio.Options
 
 	flag.StringVar(&filePath, "file", "", "Path to the file to read")
 	flag.IntVar(&iterations, "iterations", 1000, "Number of iterations to read the file")
+	flag.StringVar(&opts.Cache, "cache", fileio.CacheWarm, "Page cache state: warm, or cold to evict the file before every iteration")
+	flag.BoolVar(&opts.Direct, "direct", false, "Bypass the page cache with O_DIRECT")
 	flag.Parse()
+	defer profile.Start()()
+
+	if err := opts.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
 
 	// Default file path if not provided
 	if filePath == "" {
@@ -28,17 +42,35 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Cache eviction happens between iterations and is not part of the measured time
+	var evictTime time.Duration
 	startTime := time.Now()
 
+	// The digest hashes the bytes read in the first iteration
+	hash := sha256.New()
+
 	// Read the file multiple times
 	for i := 0; i < iterations; i++ {
-		file, err := os.Open(filePath)
+		if opts.Cache == fileio.CacheCold {
+			evictStart := time.Now()
+			if err := fileio.EvictCache(filePath); err != nil {
+				fmt.Fprintf(os.Stderr, "Error evicting file: %v\n", err)
+				os.Exit(1)
+			}
+			evictTime += time.Since(evictStart)
+		}
+
+		file, err := fileio.Open(filePath, opts)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error opening file: %v\n", err)
 			os.Exit(1)
 		}
 
-		_, err = io.Copy(io.Discard, file)
+		var dst io.Writer = io.Discard
+		if i == 0 {
+			dst = hash
+		}
+		_, err = io.Copy(dst, file)
 		file.Close()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
@@ -47,11 +79,13 @@ func main() {
 	}
 
 	endTime := time.Now()
-	totalTime := endTime.Sub(startTime)
+	totalTime := endTime.Sub(startTime) - evictTime
 	totalTimeMs := float64(totalTime.Microseconds()) / 1000.0
 	opsPerSecond := float64(iterations) / (totalTimeMs / 1000.0)
 
+	digest := hex.EncodeToString(hash.Sum(nil))
+
 	// Output JSON result to stdout
-	fmt.Printf(`{"operations":%d,"totalTimeMs":%.2f,"operationsPerSecond":%.2f}`,
-		iterations, totalTimeMs, opsPerSecond)
+	fmt.Printf(`{"operations":%d,"totalTimeMs":%.2f,"operationsPerSecond":%.2f,"digest":"%s",%s}`,
+		iterations, totalTimeMs, opsPerSecond, digest, opts.JSON())
 }
@@ -2,20 +2,34 @@ package main
 
 import (
 	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
 	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
 	"time"
+
+	"performance-benchmark-suite/benchmarks/go/internal/fileio"
+	"performance-benchmark-suite/benchmarks/go/internal/profile"
 )
 
 func main() {
 	var filePath string
 	var iterations int
+	var opts fileio.Options
 
 	flag.StringVar(&filePath, "file", "", "Path to the file to read line by line")
 	flag.IntVar(&iterations, "iterations", 10, "Number of iterations to read the file")
+	flag.StringVar(&opts.Cache, "cache", fileio.CacheWarm, "Page cache state: warm, or cold to evict the file before every iteration")
+	flag.BoolVar(&opts.Direct, "direct", false, "Bypass the page cache with O_DIRECT")
 	flag.Parse()
+	defer profile.Start()()
+
+	if err := opts.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
 
 	// Default file path if not provided
 	if filePath == "" {
@@ -28,12 +42,26 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Cache eviction happens between iterations and is not part of the measured time
+	var evictTime time.Duration
 	startTime := time.Now()
 	totalLines := 0
 
+	// The digest hashes the lines read in the first iteration
+	hash := sha256.New()
+
 	// Read the file line by line multiple times
 	for i := 0; i < iterations; i++ {
-		file, err := os.Open(filePath)
+		if opts.Cache == fileio.CacheCold {
+			evictStart := time.Now()
+			if err := fileio.EvictCache(filePath); err != nil {
+				fmt.Fprintf(os.Stderr, "Error evicting file: %v\n", err)
+				os.Exit(1)
+			}
+			evictTime += time.Since(evictStart)
+		}
+
+		file, err := fileio.Open(filePath, opts)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error opening file: %v\n", err)
 			os.Exit(1)
@@ -42,7 +70,16 @@ func main() {
 		scanner := bufio.NewScanner(file)
 		lineCount := 0
 		for scanner.Scan() {
-			_ = scanner.Text() // Read each line
+			// Every technology splits on \n, drops a trailing \r and skips
+			// empty lines
+			line := scanner.Bytes()
+			if len(line) == 0 {
+				continue
+			}
+			if i == 0 {
+				hash.Write(line)
+				hash.Write([]byte{'\n'})
+			}
 			lineCount++
 		}
 
@@ -60,11 +97,13 @@ func main() {
 	}
 
 	endTime := time.Now()
-	totalTime := endTime.Sub(startTime)
+	totalTime := endTime.Sub(startTime) - evictTime
 	totalTimeMs := float64(totalTime.Nanoseconds()) / 1e6
 	operationsPerSecond := (float64(iterations) / totalTimeMs) * 1000
 
+	digest := hex.EncodeToString(hash.Sum(nil))
+
 	// Output JSON result to stdout
-	fmt.Printf(`{"operations": %d, "totalTimeMs": %.2f, "operationsPerSecond": %.2f, "linesPerFile": %d}`,
-		iterations, totalTimeMs, operationsPerSecond, totalLines)
+	fmt.Printf(`{"operations": %d, "totalTimeMs": %.2f, "operationsPerSecond": %.2f, "linesPerFile": %d, "digest": "%s", %s}`,
+		iterations, totalTimeMs, operationsPerSecond, totalLines, digest, opts.JSON())
 }
@@ -1,22 +1,37 @@
 package main
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"time"
+
+	"performance-benchmark-suite/benchmarks/go/internal/fileio"
+	"performance-benchmark-suite/benchmarks/go/internal/profile"
 )
 
 func main() {
 	var outputPath string
 	var iterations int
 	var dataSize int
+	var opts fileio.Options
 
 	flag.StringVar(&outputPath, "output", "", "Path for output file")
 	flag.IntVar(&iterations, "iterations", 1000, "Number of iterations to write")
 	flag.IntVar(&dataSize, "size", 1024, "Size of data to write in bytes")
+	flag.StringVar(&opts.Sync, "sync", fileio.SyncNone, "Durability mode: none, fsync, fdatasync or osync")
+	flag.BoolVar(&opts.Direct, "direct", false, "Bypass the page cache with O_DIRECT")
 	flag.Parse()
+	defer profile.Start()()
+
+	if err := opts.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
 
 	// Default output path if not provided
 	if outputPath == "" {
@@ -33,14 +48,16 @@ func main() {
 
 	// Write the data multiple times
 	for i := 0; i < iterations; i++ {
-		file, err := os.Create(outputPath)
+		file, err := fileio.Create(outputPath, opts)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error creating file: %v\n", err)
 			os.Exit(1)
 		}
 
 		_, err = file.Write(testData)
-		file.Close()
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error writing file: %v\n", err)
 			os.Exit(1)
@@ -52,7 +69,30 @@ func main() {
 	totalTimeMs := float64(totalTime.Microseconds()) / 1000.0
 	opsPerSecond := float64(iterations) / (totalTimeMs / 1000.0)
 
+	// Read the output back so the digest covers what actually reached the file
+	digest, err := fileDigest(outputPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error computing digest: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Output JSON result to stdout
-	fmt.Printf(`{"operations":%d,"totalTimeMs":%.2f,"operationsPerSecond":%.2f}`,
-		iterations, totalTimeMs, opsPerSecond)
+	fmt.Printf(`{"operations":%d,"totalTimeMs":%.2f,"operationsPerSecond":%.2f,"digest":"%s",%s}`,
+		iterations, totalTimeMs, opsPerSecond, digest, opts.JSON())
+}
+
+// fileDigest returns the hex SHA-256 of a file so the runner can check that
+// every technology wrote the same bytes.
+func fileDigest(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
 }
@@ -2,22 +2,37 @@ package main
 
 import (
 	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"time"
+
+	"performance-benchmark-suite/benchmarks/go/internal/fileio"
+	"performance-benchmark-suite/benchmarks/go/internal/profile"
 )
 
 func main() {
 	var outputPath string
 	var iterations int
 	var lineCount int
+	var opts fileio.Options
 
 	flag.StringVar(&outputPath, "output", "", "Path for output file")
 	flag.IntVar(&iterations, "iterations", 10, "Number of iterations to write")
 	flag.IntVar(&lineCount, "lines", 1000000, "Number of lines to write")
+	flag.StringVar(&opts.Sync, "sync", fileio.SyncNone, "Durability mode: none, fsync, fdatasync or osync")
+	flag.BoolVar(&opts.Direct, "direct", false, "Bypass the page cache with O_DIRECT")
 	flag.Parse()
+	defer profile.Start()()
+
+	if err := opts.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
 
 	// Default output path if not provided
 	if outputPath == "" {
@@ -37,7 +52,7 @@ func main() {
 
 	// Write the data multiple times
 	for i := 0; i < iterations; i++ {
-		file, err := os.Create(outputPath)
+		file, err := fileio.Create(outputPath, opts)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error creating file: %v\n", err)
 			os.Exit(1)
@@ -63,7 +78,10 @@ func main() {
 			os.Exit(1)
 		}
 
-		file.Close()
+		if err := file.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error closing file: %v\n", err)
+			os.Exit(1)
+		}
 	}
 
 	endTime := time.Now()
@@ -71,10 +89,34 @@ func main() {
 	totalTimeMs := float64(totalTime.Nanoseconds()) / 1e6
 	operationsPerSecond := (float64(iterations) / totalTimeMs) * 1000
 
+	// Read the output back before cleaning up so the digest covers what
+	// actually reached the file
+	digest, err := fileDigest(outputPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error computing digest: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Clean up temp file
 	os.Remove(outputPath)
 
 	// Output JSON result to stdout
-	fmt.Printf(`{"operations": %d, "totalTimeMs": %.2f, "operationsPerSecond": %.2f, "linesPerIteration": %d}`,
-		iterations, totalTimeMs, operationsPerSecond, lineCount)
+	fmt.Printf(`{"operations": %d, "totalTimeMs": %.2f, "operationsPerSecond": %.2f, "linesPerIteration": %d, "digest": "%s", %s}`,
+		iterations, totalTimeMs, operationsPerSecond, lineCount, digest, opts.JSON())
+}
+
+// fileDigest returns the hex SHA-256 of a file so the runner can check that
+// every technology wrote the same bytes.
+func fileDigest(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
 }
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"performance-benchmark-suite/benchmarks/go/internal/profile"
+	"performance-benchmark-suite/proto/benchmarkpb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type benchmarkServer struct {
+	benchmarkpb.UnimplementedBenchmarkServiceServer
+}
+
+func (s *benchmarkServer) Unary(ctx context.Context, req *benchmarkpb.EchoRequest) (*benchmarkpb.EchoResponse, error) {
+	return &benchmarkpb.EchoResponse{Payload: req.Payload}, nil
+}
+
+func (s *benchmarkServer) ServerStream(req *benchmarkpb.StreamRequest, stream benchmarkpb.BenchmarkService_ServerStreamServer) error {
+	resp := &benchmarkpb.EchoResponse{Payload: req.Payload}
+	for i := int32(0); i < req.Count; i++ {
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *benchmarkServer) BidiStream(stream benchmarkpb.BenchmarkService_BidiStreamServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(&benchmarkpb.EchoResponse{Payload: req.Payload}); err != nil {
+			return err
+		}
+	}
+}
+
+func main() {
+	var port string
+
+	flag.StringVar(&port, "port", "50051", "Port for the gRPC server")
+	flag.Parse()
+	defer profile.Start()()
+
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		log.Fatalf("Server failed to start: %v\n", err)
+	}
+
+	server := grpc.NewServer()
+	benchmarkpb.RegisterBenchmarkServiceServer(server, &benchmarkServer{})
+
+	// Standard health service used by the orchestrator's readiness check
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	// Setup graceful shutdown
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	serverErrChan := make(chan error, 1)
+	go func() {
+		fmt.Printf("Starting Go gRPC server on port %s\n", port)
+
+		if err := server.Serve(listener); err != nil {
+			log.Printf("Server error: %v\n", err)
+			serverErrChan <- err
+		}
+	}()
+
+	// For CI environments, run for a maximum time instead of waiting indefinitely
+	maxRunTime := 5 * time.Minute
+	timeoutChan := time.After(maxRunTime)
+
+	select {
+	case <-sigChan:
+		fmt.Println("Received shutdown signal")
+	case <-timeoutChan:
+		fmt.Println("Maximum run time reached, shutting down")
+	case err := <-serverErrChan:
+		log.Fatalf("Server error: %v\n", err)
+	}
+
+	fmt.Println("Server shutting down...")
+
+	// Give in-flight RPCs five seconds to finish before forcing the stop
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		server.Stop()
+	}
+
+	fmt.Println("Server stopped gracefully")
+}
@@ -9,9 +9,13 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+
+	"performance-benchmark-suite/benchmarks/go/internal/profile"
 )
 
 func main() {
+	defer profile.Start()()
+
 	// Create HTTP server with handlers
 	mux := http.NewServeMux()
 
@@ -0,0 +1,19 @@
+//go:build linux && (amd64 || arm64 || riscv64 || ppc64le || s390x)
+
+package fileio
+
+import (
+	"os"
+	"syscall"
+)
+
+const fadvDontNeed = 4
+
+func fadviseDontNeed(file *os.File) error {
+	// offset 0 and length 0 cover the whole file
+	_, _, errno := syscall.Syscall6(syscall.SYS_FADVISE64, file.Fd(), 0, 0, fadvDontNeed, 0, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
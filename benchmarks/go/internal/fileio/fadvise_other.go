@@ -0,0 +1,9 @@
+//go:build !linux || !(amd64 || arm64 || riscv64 || ppc64le || s390x)
+
+package fileio
+
+import "os"
+
+func fadviseDontNeed(file *os.File) error {
+	return ErrUnsupported
+}
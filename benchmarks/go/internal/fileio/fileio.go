@@ -0,0 +1,259 @@
+// Package fileio implements the durability and cache-control modes shared by
+// the Go file benchmarks. Platform-specific pieces live in the _linux and
+// _other files; unsupported modes fail with ErrUnsupported instead of
+// silently measuring something else.
+package fileio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"unsafe"
+)
+
+var ErrUnsupported = errors.New("not supported on this platform")
+
+// Sync modes for writers
+const (
+	SyncNone      = "none"
+	SyncFsync     = "fsync"
+	SyncFdatasync = "fdatasync"
+	SyncOsync     = "osync"
+)
+
+// Cache modes for readers
+const (
+	CacheWarm = "warm"
+	CacheCold = "cold"
+)
+
+// Options selects how a benchmark talks to storage. Writers set Sync and
+// readers set Cache; an empty mode does not apply to the benchmark.
+type Options struct {
+	Sync   string
+	Direct bool
+	Cache  string
+}
+
+// Validate checks the mode values.
+func (o Options) Validate() error {
+	switch o.Sync {
+	case "", SyncNone, SyncFsync, SyncFdatasync, SyncOsync:
+	default:
+		return fmt.Errorf("invalid sync mode: %s (use 'none', 'fsync', 'fdatasync' or 'osync')", o.Sync)
+	}
+	switch o.Cache {
+	case "", CacheWarm, CacheCold:
+	default:
+		return fmt.Errorf("invalid cache mode: %s (use 'warm' or 'cold')", o.Cache)
+	}
+	return nil
+}
+
+// JSON returns the mode fields a file benchmark adds to its result.
+func (o Options) JSON() string {
+	fields := fmt.Sprintf(`"direct":%t`, o.Direct)
+	if o.Sync != "" {
+		fields += fmt.Sprintf(`,"sync":"%s"`, o.Sync)
+	}
+	if o.Cache != "" {
+		fields += fmt.Sprintf(`,"cache":"%s"`, o.Cache)
+	}
+	return fields
+}
+
+// blockSize is the alignment O_DIRECT needs for buffers, offsets and lengths
+const blockSize = 4096
+
+// chunkSize is how much direct readers and writers move per system call
+const chunkSize = 1 << 20
+
+func alignedBuffer(size int) []byte {
+	buf := make([]byte, size+blockSize)
+	offset := 0
+	if rem := int(uintptr(unsafe.Pointer(&buf[0])) % blockSize); rem != 0 {
+		offset = blockSize - rem
+	}
+	return buf[offset : offset+size]
+}
+
+// Writer writes a file using the configured mode. Close applies the sync mode
+// before closing, so the time spent making data durable is part of the write.
+type Writer struct {
+	file   *os.File
+	sync   string
+	direct bool
+	buf    []byte
+	n      int
+}
+
+// Create truncates or creates path for writing.
+func Create(path string, opts Options) (*Writer, error) {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if opts.Sync == SyncOsync {
+		flags |= os.O_SYNC
+	}
+	if opts.Direct {
+		direct, err := directFlag()
+		if err != nil {
+			return nil, fmt.Errorf("direct I/O: %v", err)
+		}
+		flags |= direct
+	}
+
+	file, err := os.OpenFile(path, flags, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Writer{file: file, sync: opts.Sync, direct: opts.Direct}
+	if opts.Direct {
+		w.buf = alignedBuffer(chunkSize)
+	}
+	return w, nil
+}
+
+func (w *Writer) Write(p []byte) (int, error) {
+	if !w.direct {
+		return w.file.Write(p)
+	}
+
+	written := 0
+	for len(p) > 0 {
+		copied := copy(w.buf[w.n:], p)
+		w.n += copied
+		p = p[copied:]
+		written += copied
+		if w.n == len(w.buf) {
+			if _, err := w.file.Write(w.buf); err != nil {
+				return written, err
+			}
+			w.n = 0
+		}
+	}
+	return written, nil
+}
+
+// WriteString lets Writer back a bufio.Writer without an extra copy.
+func (w *Writer) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Close flushes buffered direct I/O, applies the sync mode and closes the file.
+func (w *Writer) Close() error {
+	err := w.flush()
+	if err == nil {
+		err = w.syncData()
+	}
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (w *Writer) flush() error {
+	if !w.direct || w.n == 0 {
+		return nil
+	}
+
+	full := w.n - w.n%blockSize
+	if full > 0 {
+		if _, err := w.file.Write(w.buf[:full]); err != nil {
+			return err
+		}
+	}
+	// O_DIRECT cannot write a partial block, so the tail goes through the
+	// page cache
+	if tail := w.buf[full:w.n]; len(tail) > 0 {
+		if err := clearDirect(w.file); err != nil {
+			return fmt.Errorf("direct I/O tail: %v", err)
+		}
+		if _, err := w.file.Write(tail); err != nil {
+			return err
+		}
+	}
+	w.n = 0
+	return nil
+}
+
+func (w *Writer) syncData() error {
+	switch w.sync {
+	case SyncFsync:
+		return w.file.Sync()
+	case SyncFdatasync:
+		return fdatasync(w.file)
+	}
+	return nil
+}
+
+// Open opens path for reading, bypassing the page cache when direct is set.
+func Open(path string, opts Options) (io.ReadCloser, error) {
+	flags := os.O_RDONLY
+	if opts.Direct {
+		direct, err := directFlag()
+		if err != nil {
+			return nil, fmt.Errorf("direct I/O: %v", err)
+		}
+		flags |= direct
+	}
+
+	file, err := os.OpenFile(path, flags, 0)
+	if err != nil {
+		return nil, err
+	}
+	if !opts.Direct {
+		return file, nil
+	}
+	return &directReader{file: file, buf: alignedBuffer(chunkSize)}, nil
+}
+
+// directReader reads aligned chunks and hands them out in whatever sizes the
+// caller asks for, since callers like bufio.Scanner use unaligned buffers.
+type directReader struct {
+	file *os.File
+	buf  []byte
+	r, w int
+	err  error
+}
+
+func (d *directReader) Read(p []byte) (int, error) {
+	if d.r == d.w {
+		if d.err != nil {
+			return 0, d.err
+		}
+		d.r = 0
+		d.w, d.err = d.file.Read(d.buf)
+		if d.w == 0 {
+			if d.err == nil {
+				d.err = io.EOF
+			}
+			return 0, d.err
+		}
+	}
+	n := copy(p, d.buf[d.r:d.w])
+	d.r += n
+	return n, nil
+}
+
+func (d *directReader) Close() error {
+	return d.file.Close()
+}
+
+// EvictCache drops path from the page cache so the next read goes to storage.
+func EvictCache(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Dirty pages cannot be dropped, so write them back first
+	if err := file.Sync(); err != nil {
+		return err
+	}
+	if err := fadviseDontNeed(file); err != nil {
+		return fmt.Errorf("evicting %s from the page cache: %v", path, err)
+	}
+	return nil
+}
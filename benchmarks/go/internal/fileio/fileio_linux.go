@@ -0,0 +1,28 @@
+//go:build linux
+
+package fileio
+
+import (
+	"os"
+	"syscall"
+)
+
+func directFlag() (int, error) {
+	return syscall.O_DIRECT, nil
+}
+
+func clearDirect(file *os.File) error {
+	flags, _, errno := syscall.Syscall(syscall.SYS_FCNTL, file.Fd(), syscall.F_GETFL, 0)
+	if errno != 0 {
+		return errno
+	}
+	_, _, errno = syscall.Syscall(syscall.SYS_FCNTL, file.Fd(), syscall.F_SETFL, flags&^syscall.O_DIRECT)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func fdatasync(file *os.File) error {
+	return syscall.Fdatasync(int(file.Fd()))
+}
@@ -0,0 +1,17 @@
+//go:build !linux
+
+package fileio
+
+import "os"
+
+func directFlag() (int, error) {
+	return 0, ErrUnsupported
+}
+
+func clearDirect(file *os.File) error {
+	return ErrUnsupported
+}
+
+func fdatasync(file *os.File) error {
+	return ErrUnsupported
+}
@@ -0,0 +1,106 @@
+// Package profile lets the orchestrator collect pprof profiles and execution
+// traces from the Go benchmarks. Profiling is requested through environment
+// variables so benchmarks keep their own flags untouched.
+package profile
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"sync"
+	"syscall"
+)
+
+// Environment variables naming the files to write each profile to
+const (
+	CPUEnv   = "BENCHMARK_CPU_PROFILE"
+	HeapEnv  = "BENCHMARK_HEAP_PROFILE"
+	TraceEnv = "BENCHMARK_TRACE"
+)
+
+// Start begins the profiles requested in the environment and returns a
+// function that writes them out; call it with defer right after flag.Parse.
+// While profiling, SIGINT and SIGTERM also write the profiles before exiting,
+// so servers stopped by the orchestrator still produce them. Without any of
+// the variables set it does nothing.
+func Start() func() {
+	cpuPath, heapPath, tracePath := os.Getenv(CPUEnv), os.Getenv(HeapEnv), os.Getenv(TraceEnv)
+	if cpuPath == "" && heapPath == "" && tracePath == "" {
+		return func() {}
+	}
+
+	var cpuFile, traceFile *os.File
+	if cpuPath != "" {
+		cpuFile = create(cpuPath)
+		if cpuFile != nil {
+			if err := pprof.StartCPUProfile(cpuFile); err != nil {
+				fmt.Fprintf(os.Stderr, "profile: failed to start CPU profile: %v\n", err)
+				cpuFile.Close()
+				cpuFile = nil
+			}
+		}
+	}
+	if tracePath != "" {
+		traceFile = create(tracePath)
+		if traceFile != nil {
+			if err := trace.Start(traceFile); err != nil {
+				fmt.Fprintf(os.Stderr, "profile: failed to start trace: %v\n", err)
+				traceFile.Close()
+				traceFile = nil
+			}
+		}
+	}
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			if cpuFile != nil {
+				pprof.StopCPUProfile()
+				cpuFile.Close()
+			}
+			if traceFile != nil {
+				trace.Stop()
+				traceFile.Close()
+			}
+			if heapPath != "" {
+				writeHeap(heapPath)
+			}
+		})
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		stop()
+		os.Exit(0)
+	}()
+
+	return stop
+}
+
+func writeHeap(path string) {
+	file := create(path)
+	if file == nil {
+		return
+	}
+	defer file.Close()
+
+	// Collect garbage first so the profile shows live memory
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(file); err != nil {
+		fmt.Fprintf(os.Stderr, "profile: failed to write heap profile: %v\n", err)
+	}
+}
+
+func create(path string) *os.File {
+	file, err := os.Create(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "profile: %v\n", err)
+		return nil
+	}
+	return file
+}
@@ -0,0 +1,103 @@
+// Package records holds the JSON record types shared by the Go JSON
+// benchmarks, so every benchmark decodes a shape into the same structs.
+package records
+
+// LineItem is the record written by json_write_lines and stored in xlarge_lines.json
+type LineItem struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Category    string   `json:"category"`
+	Priority    string   `json:"priority"`
+	Tags        []string `json:"tags"`
+	Timestamp   string   `json:"timestamp"`
+	Value       int      `json:"value"`
+	Active      bool     `json:"active"`
+}
+
+// FlatRecord, NestedRecord, NumericRecord and StringRecord are the payload
+// shapes produced by `benchmark-cli data generate`
+
+type FlatRecord struct {
+	ID        int     `json:"id"`
+	Name      string  `json:"name"`
+	Email     string  `json:"email"`
+	Active    bool    `json:"active"`
+	Score     float64 `json:"score"`
+	Age       int     `json:"age"`
+	Country   string  `json:"country"`
+	Category  string  `json:"category"`
+	CreatedAt string  `json:"created_at"`
+}
+
+type NestedRecord struct {
+	ID   int `json:"id"`
+	User struct {
+		ID      int    `json:"id"`
+		Name    string `json:"name"`
+		Address struct {
+			Street string `json:"street"`
+			City   string `json:"city"`
+			Zip    string `json:"zip"`
+			Geo    struct {
+				Lat float64 `json:"lat"`
+				Lng float64 `json:"lng"`
+			} `json:"geo"`
+		} `json:"address"`
+	} `json:"user"`
+	Order struct {
+		Items []struct {
+			SKU        string  `json:"sku"`
+			Quantity   int     `json:"quantity"`
+			Price      float64 `json:"price"`
+			Attributes struct {
+				Color string `json:"color"`
+				Size  string `json:"size"`
+			} `json:"attributes"`
+		} `json:"items"`
+		Totals struct {
+			Subtotal float64 `json:"subtotal"`
+			Tax      float64 `json:"tax"`
+			Total    float64 `json:"total"`
+		} `json:"totals"`
+	} `json:"order"`
+	Meta struct {
+		Tags    []string `json:"tags"`
+		History []struct {
+			Timestamp string `json:"timestamp"`
+			Event     string `json:"event"`
+		} `json:"history"`
+	} `json:"meta"`
+}
+
+type NumericRecord struct {
+	ID     int       `json:"id"`
+	Values []float64 `json:"values"`
+	Counts []int     `json:"counts"`
+	Stats  struct {
+		Min    float64 `json:"min"`
+		Max    float64 `json:"max"`
+		Mean   float64 `json:"mean"`
+		Stddev float64 `json:"stddev"`
+	} `json:"stats"`
+}
+
+type StringRecord struct {
+	ID     int      `json:"id"`
+	Title  string   `json:"title"`
+	Body   string   `json:"body"`
+	Author string   `json:"author"`
+	URL    string   `json:"url"`
+	Tags   []string `json:"tags"`
+}
+
+func (r LineItem) RecordID() int      { return r.ID }
+func (r FlatRecord) RecordID() int    { return r.ID }
+func (r NestedRecord) RecordID() int  { return r.ID }
+func (r NumericRecord) RecordID() int { return r.ID }
+func (r StringRecord) RecordID() int  { return r.ID }
+
+// Record is implemented by every typed shape so decoded ids can feed a digest
+type Record interface {
+	RecordID() int
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"performance-benchmark-suite/benchmarks/go/internal/profile"
+	"performance-benchmark-suite/benchmarks/go/internal/records"
+)
+
+// shapeDecoder decodes a JSON array document of one shape into typed records.
+// When ids is not nil every decoded record id is written to it.
+type shapeDecoder struct {
+	typed  func(data []byte, ids io.Writer) (int, error)
+	stream func(r io.Reader, ids io.Writer) (int, error)
+}
+
+var shapes = map[string]shapeDecoder{
+	"flat":    decoderFor[records.FlatRecord](),
+	"nested":  decoderFor[records.NestedRecord](),
+	"numeric": decoderFor[records.NumericRecord](),
+	"string":  decoderFor[records.StringRecord](),
+}
+
+func decoderFor[T records.Record]() shapeDecoder {
+	return shapeDecoder{typed: decodeTyped[T], stream: decodeStreaming[T]}
+}
+
+// decodeTyped materializes the whole document as a slice of records
+func decodeTyped[T records.Record](data []byte, ids io.Writer) (int, error) {
+	var decoded []T
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return 0, err
+	}
+	for _, record := range decoded {
+		writeID(ids, record.RecordID())
+	}
+	return len(decoded), nil
+}
+
+// decodeStreaming walks the array element by element without holding the
+// decoded document in memory
+func decodeStreaming[T records.Record](r io.Reader, ids io.Writer) (int, error) {
+	decoder := json.NewDecoder(r)
+	token, err := decoder.Token()
+	if err != nil {
+		return 0, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return 0, fmt.Errorf("expected a JSON array, got %v", token)
+	}
+
+	count := 0
+	for decoder.More() {
+		var record T
+		if err := decoder.Decode(&record); err != nil {
+			return 0, err
+		}
+		writeID(ids, record.RecordID())
+		count++
+	}
+	if _, err := decoder.Token(); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// decodeUntyped decodes into generic maps and slices
+func decodeUntyped(data []byte, ids io.Writer) (int, error) {
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return 0, err
+	}
+	items, ok := document.([]interface{})
+	if !ok {
+		return 1, nil
+	}
+	for _, item := range items {
+		object, _ := item.(map[string]interface{})
+		id, _ := object["id"].(float64)
+		writeID(ids, int(id))
+	}
+	return len(items), nil
+}
+
+func writeID(ids io.Writer, id int) {
+	if ids != nil {
+		fmt.Fprintf(ids, "%d\n", id)
+	}
+}
+
+func main() {
+	var filePath string
+	var iterations int
+	var decodeMode string
+	var shape string
+
+	flag.StringVar(&filePath, "file", "", "Path to the JSON document to parse")
+	flag.IntVar(&iterations, "iterations", 10, "Number of times to parse the document")
+	flag.StringVar(&decodeMode, "decode-mode", "untyped", "Decode mode: typed, untyped or streaming")
+	flag.StringVar(&shape, "shape", "flat", "Payload shape: flat, nested, numeric or string")
+	flag.Parse()
+	defer profile.Start()()
+
+	decoder, ok := shapes[shape]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Invalid shape: %s. Use 'flat', 'nested', 'numeric' or 'string'\n", shape)
+		os.Exit(1)
+	}
+	if decodeMode != "typed" && decodeMode != "untyped" && decodeMode != "streaming" {
+		fmt.Fprintf(os.Stderr, "Invalid decode mode: %s. Use 'typed', 'untyped' or 'streaming'\n", decodeMode)
+		os.Exit(1)
+	}
+
+	// Default file path if not provided
+	if filePath == "" {
+		filePath = filepath.Join("test_data", fmt.Sprintf("shape_%s.json", shape))
+	}
+
+	// Check if file exists
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "File not found: %s\n", filePath)
+		os.Exit(1)
+	}
+
+	// Load the document once so every mode parses from memory
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
+		os.Exit(1)
+	}
+
+	// The digest hashes the ids decoded in the first iteration, so it proves
+	// every record was parsed without depending on how it was represented
+	digest := sha256.New()
+
+	startTime := time.Now()
+	recordCount := 0
+
+	for i := 0; i < iterations; i++ {
+		var ids io.Writer
+		if i == 0 {
+			ids = digest
+		}
+
+		var count int
+		switch decodeMode {
+		case "typed":
+			count, err = decoder.typed(data, ids)
+		case "untyped":
+			count, err = decodeUntyped(data, ids)
+		case "streaming":
+			count, err = decoder.stream(bytes.NewReader(data), ids)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing JSON: %v\n", err)
+			os.Exit(1)
+		}
+		recordCount = count
+	}
+
+	endTime := time.Now()
+	totalTime := endTime.Sub(startTime)
+	totalTimeMs := float64(totalTime.Microseconds()) / 1000.0
+	opsPerSecond := float64(iterations) / (totalTimeMs / 1000.0)
+
+	// Output JSON result to stdout
+	fmt.Printf(`{"operations":%d,"totalTimeMs":%.2f,"operationsPerSecond":%.2f,"records":%d,"bytes":%d,"decodeMode":"%s","shape":"%s","digest":"%s"}`,
+		iterations, totalTimeMs, opsPerSecond, recordCount, len(data), decodeMode, shape, hex.EncodeToString(digest.Sum(nil)))
+}
@@ -2,25 +2,131 @@ package main
 
 import (
 	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"time"
+
+	"performance-benchmark-suite/benchmarks/go/internal/profile"
+	"performance-benchmark-suite/benchmarks/go/internal/records"
 )
 
+// lineDecoder decodes one JSON lines file of a given shape into typed records.
+// When ids is not nil every decoded record id is written to it.
+type lineDecoder struct {
+	typed  func(scanner *bufio.Scanner, ids io.Writer) (int, error)
+	stream func(r io.Reader, ids io.Writer) (int, error)
+}
+
+var shapes = map[string]lineDecoder{
+	"items":   decoderFor[records.LineItem](),
+	"flat":    decoderFor[records.FlatRecord](),
+	"nested":  decoderFor[records.NestedRecord](),
+	"numeric": decoderFor[records.NumericRecord](),
+	"string":  decoderFor[records.StringRecord](),
+}
+
+func decoderFor[T records.Record]() lineDecoder {
+	return lineDecoder{typed: scanLines[T], stream: decodeStreaming[T]}
+}
+
+// scanLines unmarshals every non-empty line into a T
+func scanLines[T records.Record](scanner *bufio.Scanner, ids io.Writer) (int, error) {
+	lineCount := 0
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var record T
+		if err := json.Unmarshal(line, &record); err != nil {
+			return 0, fmt.Errorf("line %d: %v", lineCount+1, err)
+		}
+		writeID(ids, record.RecordID())
+		lineCount++
+	}
+	return lineCount, scanner.Err()
+}
+
+// scanLinesUntyped unmarshals every non-empty line into a generic map
+func scanLinesUntyped(scanner *bufio.Scanner, ids io.Writer) (int, error) {
+	lineCount := 0
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var jsonObj map[string]interface{}
+		if err := json.Unmarshal(line, &jsonObj); err != nil {
+			return 0, fmt.Errorf("line %d: %v", lineCount+1, err)
+		}
+		id, _ := jsonObj["id"].(float64)
+		writeID(ids, int(id))
+		lineCount++
+	}
+	return lineCount, scanner.Err()
+}
+
+// decodeStreaming reads consecutive values straight from the file without
+// splitting it into lines first
+func decodeStreaming[T records.Record](r io.Reader, ids io.Writer) (int, error) {
+	decoder := json.NewDecoder(r)
+	count := 0
+	for {
+		var record T
+		err := decoder.Decode(&record)
+		if err == io.EOF {
+			return count, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("record %d: %v", count+1, err)
+		}
+		writeID(ids, record.RecordID())
+		count++
+	}
+}
+
+func writeID(ids io.Writer, id int) {
+	if ids != nil {
+		fmt.Fprintf(ids, "%d\n", id)
+	}
+}
+
 func main() {
 	var filePath string
 	var iterations int
+	var decodeMode string
+	var shape string
 
 	flag.StringVar(&filePath, "file", "", "Path to the JSON lines file to read")
 	flag.IntVar(&iterations, "iterations", 10, "Number of iterations to read the file")
+	flag.StringVar(&decodeMode, "decode-mode", "untyped", "Decode mode: typed, untyped or streaming")
+	flag.StringVar(&shape, "shape", "items", "Payload shape: items, flat, nested, numeric or string")
 	flag.Parse()
+	defer profile.Start()()
+
+	decoder, ok := shapes[shape]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Invalid shape: %s. Use 'items', 'flat', 'nested', 'numeric' or 'string'\n", shape)
+		os.Exit(1)
+	}
+	if decodeMode != "typed" && decodeMode != "untyped" && decodeMode != "streaming" {
+		fmt.Fprintf(os.Stderr, "Invalid decode mode: %s. Use 'typed', 'untyped' or 'streaming'\n", decodeMode)
+		os.Exit(1)
+	}
 
 	// Default file path if not provided
 	if filePath == "" {
-		filePath = filepath.Join("test_data", "xlarge_lines.json")
+		if shape == "items" {
+			filePath = filepath.Join("test_data", "xlarge_lines.json")
+		} else {
+			filePath = filepath.Join("test_data", fmt.Sprintf("shape_%s_lines.json", shape))
+		}
 	}
 
 	// Check if file exists
@@ -29,6 +135,10 @@ func main() {
 		os.Exit(1)
 	}
 
+	// The digest hashes the ids decoded in the first iteration, so it proves
+	// every record was parsed without depending on how it was represented
+	digest := sha256.New()
+
 	startTime := time.Now()
 	totalLines := 0
 
@@ -40,30 +150,27 @@ func main() {
 			os.Exit(1)
 		}
 
-		scanner := bufio.NewScanner(file)
-		lineCount := 0
-		for scanner.Scan() {
-			line := scanner.Text()
-			if line != "" {
-				var jsonObj map[string]interface{}
-				err := json.Unmarshal([]byte(line), &jsonObj)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error parsing JSON line %d: %v\n", lineCount+1, err)
-					file.Close()
-					os.Exit(1)
-				}
-				lineCount++
-			}
+		var ids io.Writer
+		if i == 0 {
+			ids = digest
 		}
 
-		if err := scanner.Err(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
-			file.Close()
-			os.Exit(1)
+		var lineCount int
+		switch decodeMode {
+		case "typed":
+			lineCount, err = decoder.typed(bufio.NewScanner(file), ids)
+		case "untyped":
+			lineCount, err = scanLinesUntyped(bufio.NewScanner(file), ids)
+		case "streaming":
+			lineCount, err = decoder.stream(bufio.NewReader(file), ids)
 		}
-
 		file.Close()
 
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing JSON lines: %v\n", err)
+			os.Exit(1)
+		}
+
 		if i == 0 {
 			totalLines = lineCount
 		}
@@ -75,6 +182,6 @@ func main() {
 	operationsPerSecond := (float64(iterations) / totalTimeMs) * 1000
 
 	// Output JSON result to stdout
-	fmt.Printf(`{"operations": %d, "totalTimeMs": %.2f, "operationsPerSecond": %.2f, "linesPerFile": %d}`,
-		iterations, totalTimeMs, operationsPerSecond, totalLines)
+	fmt.Printf(`{"operations": %d, "totalTimeMs": %.2f, "operationsPerSecond": %.2f, "linesPerFile": %d, "decodeMode": "%s", "shape": "%s", "digest": "%s"}`,
+		iterations, totalTimeMs, operationsPerSecond, totalLines, decodeMode, shape, hex.EncodeToString(digest.Sum(nil)))
 }
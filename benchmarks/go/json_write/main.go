@@ -1,12 +1,18 @@
 package main
 
 import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"time"
+
+	"performance-benchmark-suite/benchmarks/go/internal/profile"
 )
 
 type TestData struct {
@@ -20,18 +26,27 @@ func main() {
 	var outputPath string
 	var iterations int
 	var dataSize int
+	var decodeMode string
 
 	flag.StringVar(&outputPath, "output", "", "Path for output JSON file")
 	flag.IntVar(&iterations, "iterations", 1000, "Number of iterations to write")
 	flag.IntVar(&dataSize, "size", 100, "Number of items in the JSON array")
+	flag.StringVar(&decodeMode, "decode-mode", "typed", "Encoding mode: typed, untyped or streaming")
 	flag.Parse()
+	defer profile.Start()()
+
+	if decodeMode != "typed" && decodeMode != "untyped" && decodeMode != "streaming" {
+		fmt.Fprintf(os.Stderr, "Invalid decode mode: %s. Use 'typed', 'untyped' or 'streaming'\n", decodeMode)
+		os.Exit(1)
+	}
 
 	// Default output path if not provided
 	if outputPath == "" {
 		outputPath = filepath.Join("test_data", "temp_output.json")
 	}
 
-	// Create test data
+	// Create test data. The creation time is fixed so every technology writes
+	// an identical document.
 	testData := make([]TestData, dataSize)
 	for i := 0; i < dataSize; i++ {
 		testData[i] = TestData{
@@ -39,35 +54,42 @@ func main() {
 			Name: fmt.Sprintf("Item_%d", i),
 			Tags: []string{"tag1", "tag2", "tag3"},
 			Metadata: map[string]interface{}{
-				"created": time.Now().Unix(),
+				"created": 1700000000,
 				"version": "1.0",
 				"active":  true,
 			},
 		}
 	}
 
+	// The untyped mode encodes the same items as generic maps
+	var untypedData []map[string]interface{}
+	if decodeMode == "untyped" {
+		untypedData = make([]map[string]interface{}, dataSize)
+		for i, item := range testData {
+			untypedData[i] = map[string]interface{}{
+				"id":       item.ID,
+				"name":     item.Name,
+				"tags":     item.Tags,
+				"metadata": item.Metadata,
+			}
+		}
+	}
+
 	startTime := time.Now()
 
 	// Serialize and write JSON multiple times
 	for i := 0; i < iterations; i++ {
-		// Serialize to JSON
-		jsonData, err := json.MarshalIndent(testData, "", "  ")
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error marshaling JSON: %v\n", err)
-			os.Exit(1)
-		}
-
-		// Write to file
-		file, err := os.Create(outputPath)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error creating file: %v\n", err)
-			os.Exit(1)
+		var err error
+		switch decodeMode {
+		case "typed":
+			err = writeDocument(outputPath, testData)
+		case "untyped":
+			err = writeDocument(outputPath, untypedData)
+		case "streaming":
+			err = writeStreaming(outputPath, testData)
 		}
-
-		_, err = file.Write(jsonData)
-		file.Close()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error writing file: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error writing JSON: %v\n", err)
 			os.Exit(1)
 		}
 	}
@@ -77,7 +99,77 @@ func main() {
 	totalTimeMs := float64(totalTime.Microseconds()) / 1000.0
 	opsPerSecond := float64(iterations) / (totalTimeMs / 1000.0)
 
+	// Read the output back so the digest covers what actually reached the file
+	digest, err := fileDigest(outputPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error computing digest: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Output JSON result to stdout
-	fmt.Printf(`{"operations":%d,"totalTimeMs":%.2f,"operationsPerSecond":%.2f}`,
-		iterations, totalTimeMs, opsPerSecond)
+	fmt.Printf(`{"operations":%d,"totalTimeMs":%.2f,"operationsPerSecond":%.2f,"decodeMode":"%s","digest":"%s"}`,
+		iterations, totalTimeMs, opsPerSecond, decodeMode, digest)
+}
+
+// writeDocument serializes the whole document in memory and writes it at once
+func writeDocument(outputPath string, data interface{}) error {
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling JSON: %v", err)
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("creating file: %v", err)
+	}
+
+	_, err = file.Write(jsonData)
+	file.Close()
+	return err
+}
+
+// writeStreaming encodes one item at a time through a buffered writer. The
+// output is byte-for-byte identical to writeDocument for the same items.
+func writeStreaming(outputPath string, items []TestData) error {
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("creating file: %v", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	if len(items) == 0 {
+		writer.WriteString("[]")
+		return writer.Flush()
+	}
+
+	writer.WriteString("[\n  ")
+	for i, item := range items {
+		if i > 0 {
+			writer.WriteString(",\n  ")
+		}
+		itemJSON, err := json.MarshalIndent(item, "  ", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling JSON: %v", err)
+		}
+		writer.Write(itemJSON)
+	}
+	writer.WriteString("\n]")
+	return writer.Flush()
+}
+
+// fileDigest returns the hex SHA-256 of a file so the runner can check that
+// every technology wrote the same document.
+func fileDigest(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
 }
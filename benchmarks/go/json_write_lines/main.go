@@ -2,35 +2,37 @@ package main
 
 import (
 	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"time"
-)
 
-type SampleItem struct {
-	ID          int      `json:"id"`
-	Name        string   `json:"name"`
-	Description string   `json:"description"`
-	Category    string   `json:"category"`
-	Priority    string   `json:"priority"`
-	Tags        []string `json:"tags"`
-	Timestamp   string   `json:"timestamp"`
-	Value       int      `json:"value"`
-	Active      bool     `json:"active"`
-}
+	"performance-benchmark-suite/benchmarks/go/internal/profile"
+	"performance-benchmark-suite/benchmarks/go/internal/records"
+)
 
 func main() {
 	var outputPath string
 	var iterations int
 	var lineCount int
+	var decodeMode string
 
 	flag.StringVar(&outputPath, "output", "", "Path for output JSON lines file")
 	flag.IntVar(&iterations, "iterations", 10, "Number of iterations to write")
 	flag.IntVar(&lineCount, "lines", 1000000, "Number of JSON lines to write")
+	flag.StringVar(&decodeMode, "decode-mode", "typed", "Encoding mode: typed, untyped or streaming")
 	flag.Parse()
+	defer profile.Start()()
+
+	if decodeMode != "typed" && decodeMode != "untyped" && decodeMode != "streaming" {
+		fmt.Fprintf(os.Stderr, "Invalid decode mode: %s. Use 'typed', 'untyped' or 'streaming'\n", decodeMode)
+		os.Exit(1)
+	}
 
 	// Default output path if not provided
 	if outputPath == "" {
@@ -48,6 +50,10 @@ func main() {
 		{"json", "test"},
 	}
 
+	// Timestamps are derived from a fixed base time so every technology
+	// writes identical lines
+	baseTime := time.Unix(1700000000, 0).UTC()
+
 	startTime := time.Now()
 
 	// Write the JSON lines multiple times
@@ -59,40 +65,45 @@ func main() {
 		}
 
 		writer := bufio.NewWriter(file)
+		encoder := json.NewEncoder(writer)
 
 		for j := 0; j < lineCount; j++ {
-			item := SampleItem{
+			item := records.LineItem{
 				ID:          j + 1,
 				Name:        fmt.Sprintf("Item_%07d", j+1),
 				Description: fmt.Sprintf("Sample item %d for benchmarking JSON line operations", j+1),
 				Category:    sampleCategories[j%len(sampleCategories)],
 				Priority:    samplePriorities[j%len(samplePriorities)],
 				Tags:        sampleTags[j%len(sampleTags)],
-				Timestamp:   time.Now().Add(time.Duration(j) * time.Second).Format(time.RFC3339),
+				Timestamp:   baseTime.Add(time.Duration(j) * time.Second).Format("2006-01-02T15:04:05.000Z"),
 				Value:       j * 100,
 				Active:      j%3 == 0,
 			}
 
-			jsonBytes, err := json.Marshal(item)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error marshaling JSON: %v\n", err)
-				file.Close()
-				os.Exit(1)
+			switch decodeMode {
+			case "typed":
+				err = writeLine(writer, item)
+			case "untyped":
+				err = writeLine(writer, map[string]interface{}{
+					"id":          item.ID,
+					"name":        item.Name,
+					"description": item.Description,
+					"category":    item.Category,
+					"priority":    item.Priority,
+					"tags":        item.Tags,
+					"timestamp":   item.Timestamp,
+					"value":       item.Value,
+					"active":      item.Active,
+				})
+			case "streaming":
+				// Encoder.Encode appends the newline itself
+				err = encoder.Encode(item)
 			}
-
-			_, err = writer.Write(jsonBytes)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error writing JSON line: %v\n", err)
 				file.Close()
 				os.Exit(1)
 			}
-
-			_, err = writer.WriteString("\n")
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error writing newline: %v\n", err)
-				file.Close()
-				os.Exit(1)
-			}
 		}
 
 		err = writer.Flush()
@@ -110,10 +121,46 @@ func main() {
 	totalTimeMs := float64(totalTime.Nanoseconds()) / 1e6
 	operationsPerSecond := (float64(iterations) / totalTimeMs) * 1000
 
+	// Read the output back before cleaning up so the digest covers what
+	// actually reached the file
+	digest, err := fileDigest(outputPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error computing digest: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Clean up temp file
 	os.Remove(outputPath)
 
 	// Output JSON result to stdout
-	fmt.Printf(`{"operations": %d, "totalTimeMs": %.2f, "operationsPerSecond": %.2f, "linesPerIteration": %d}`,
-		iterations, totalTimeMs, operationsPerSecond, lineCount)
+	fmt.Printf(`{"operations": %d, "totalTimeMs": %.2f, "operationsPerSecond": %.2f, "linesPerIteration": %d, "decodeMode": "%s", "digest": "%s"}`,
+		iterations, totalTimeMs, operationsPerSecond, lineCount, decodeMode, digest)
+}
+
+// writeLine marshals one value and writes it followed by a newline
+func writeLine(writer *bufio.Writer, value interface{}) error {
+	jsonBytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("marshaling JSON: %v", err)
+	}
+	if _, err := writer.Write(jsonBytes); err != nil {
+		return err
+	}
+	return writer.WriteByte('\n')
+}
+
+// fileDigest returns the hex SHA-256 of a file so the runner can check that
+// every technology wrote the same lines.
+func fileDigest(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
 }
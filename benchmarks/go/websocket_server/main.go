@@ -0,0 +1,374 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"performance-benchmark-suite/benchmarks/go/internal/profile"
+)
+
+// Minimal RFC 6455 server so the benchmark stays dependency free like the
+// other Go benchmarks.
+
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xA
+)
+
+const maxMessageSize = 16 << 20
+
+const (
+	// writeTimeout bounds every frame write so a client that stops reading
+	// cannot hold its connection's writer forever
+	writeTimeout = 5 * time.Second
+	// sendQueueSize is how many broadcast frames may wait for a client
+	// before it counts as fallen behind
+	sendQueueSize = 256
+)
+
+type wsConn struct {
+	conn    net.Conn
+	reader  *bufio.Reader
+	writeMu sync.Mutex
+	// send queues broadcast frames for writeLoop; nil on echo connections
+	send chan []byte
+}
+
+// hub tracks the connections of the broadcast endpoint
+type hub struct {
+	mu    sync.RWMutex
+	conns map[*wsConn]struct{}
+}
+
+// add registers c and starts the goroutine writing its broadcast frames
+func (h *hub) add(c *wsConn) {
+	c.send = make(chan []byte, sendQueueSize)
+	h.mu.Lock()
+	h.conns[c] = struct{}{}
+	h.mu.Unlock()
+	go c.writeLoop()
+}
+
+// remove unregisters c and stops its writer. It is safe to call twice.
+func (h *hub) remove(c *wsConn) {
+	h.mu.Lock()
+	if _, ok := h.conns[c]; ok {
+		delete(h.conns, c)
+		close(c.send)
+	}
+	h.mu.Unlock()
+}
+
+// broadcast queues one encoded frame for every client without waiting on
+// any of them. A client whose queue is full has fallen behind and is
+// dropped, so a slow reader cannot stall the others.
+func (h *hub) broadcast(opcode byte, payload []byte) {
+	frame := encodeFrame(opcode, payload)
+
+	var slow []*wsConn
+	h.mu.RLock()
+	for c := range h.conns {
+		select {
+		case c.send <- frame:
+		default:
+			slow = append(slow, c)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, c := range slow {
+		h.remove(c)
+		c.conn.Close()
+	}
+}
+
+func upgrade(w http.ResponseWriter, r *http.Request) (*wsConn, error) {
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") ||
+		!strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade") {
+		http.Error(w, "expected websocket upgrade", http.StatusBadRequest)
+		return nil, errors.New("not a websocket upgrade request")
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		http.Error(w, "missing Sec-WebSocket-Key", http.StatusBadRequest)
+		return nil, errors.New("missing Sec-WebSocket-Key")
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "hijacking not supported", http.StatusInternalServerError)
+		return nil, errors.New("hijacking not supported")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	accept := base64.StdEncoding.EncodeToString(sum[:])
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + accept + "\r\n\r\n"
+	if _, err := conn.Write([]byte(response)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &wsConn{conn: conn, reader: rw.Reader}, nil
+}
+
+// readFrame reads a single client frame and unmasks its payload
+func (c *wsConn) readFrame() (fin bool, opcode byte, payload []byte, err error) {
+	var header [2]byte
+	if _, err = io.ReadFull(c.reader, header[:]); err != nil {
+		return
+	}
+	fin = header[0]&0x80 != 0
+	opcode = header[0] & 0x0F
+	masked := header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7F)
+
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err = io.ReadFull(c.reader, ext[:]); err != nil {
+			return
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err = io.ReadFull(c.reader, ext[:]); err != nil {
+			return
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if length > maxMessageSize {
+		err = fmt.Errorf("frame too large: %d bytes", length)
+		return
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err = io.ReadFull(c.reader, mask[:]); err != nil {
+			return
+		}
+	}
+
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(c.reader, payload); err != nil {
+		return
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return
+}
+
+// readMessage reassembles fragmented messages and answers control frames
+func (c *wsConn) readMessage() (byte, []byte, error) {
+	var message []byte
+	var messageOpcode byte
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch opcode {
+		case opPing:
+			c.writeFrame(opPong, payload)
+			continue
+		case opPong:
+			continue
+		case opClose:
+			c.writeFrame(opClose, payload)
+			return opClose, nil, io.EOF
+		case opText, opBinary:
+			messageOpcode = opcode
+			message = payload
+		case opContinuation:
+			message = append(message, payload...)
+			if len(message) > maxMessageSize {
+				return 0, nil, fmt.Errorf("message too large: %d bytes", len(message))
+			}
+		default:
+			return 0, nil, fmt.Errorf("unknown opcode: %d", opcode)
+		}
+
+		if fin {
+			return messageOpcode, message, nil
+		}
+	}
+}
+
+// encodeFrame builds an unmasked, unfragmented server frame in one buffer
+func encodeFrame(opcode byte, payload []byte) []byte {
+	frame := make([]byte, 0, 10+len(payload))
+	frame = append(frame, 0x80|opcode)
+	switch {
+	case len(payload) < 126:
+		frame = append(frame, byte(len(payload)))
+	case len(payload) <= 0xFFFF:
+		frame = append(frame, 126, byte(len(payload)>>8), byte(len(payload)))
+	default:
+		frame = append(frame, 127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(len(payload)))
+	}
+	return append(frame, payload...)
+}
+
+// writeFrame writes a server frame with a single write call
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	return c.write(encodeFrame(opcode, payload))
+}
+
+func (c *wsConn) write(frame []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+// writeLoop writes queued broadcast frames until the queue is closed. A
+// failed write closes the connection, which ends its reader too.
+func (c *wsConn) writeLoop() {
+	for frame := range c.send {
+		if err := c.write(frame); err != nil {
+			c.conn.Close()
+			for range c.send {
+			}
+			return
+		}
+	}
+}
+
+func main() {
+	var port string
+
+	flag.StringVar(&port, "port", "3000", "Port for the WebSocket server")
+	flag.Parse()
+	defer profile.Start()()
+
+	clients := &hub{conns: make(map[*wsConn]struct{})}
+
+	mux := http.NewServeMux()
+
+	// Echo every message back to its sender
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrade(w, r)
+		if err != nil {
+			return
+		}
+		defer c.conn.Close()
+
+		for {
+			opcode, payload, err := c.readMessage()
+			if err != nil {
+				return
+			}
+			if err := c.writeFrame(opcode, payload); err != nil {
+				return
+			}
+		}
+	})
+
+	// Fan every message out to all connected clients, including the sender
+	mux.HandleFunc("/broadcast", func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrade(w, r)
+		if err != nil {
+			return
+		}
+		clients.add(c)
+		defer func() {
+			clients.remove(c)
+			c.conn.Close()
+		}()
+
+		for {
+			opcode, payload, err := c.readMessage()
+			if err != nil {
+				return
+			}
+			clients.broadcast(opcode, payload)
+		}
+	})
+
+	// Health check endpoint
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"status": "healthy"}`))
+	})
+
+	server := &http.Server{
+		Addr:    ":" + port,
+		Handler: mux,
+	}
+
+	// Setup graceful shutdown
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	serverErrChan := make(chan error, 1)
+	go func() {
+		fmt.Printf("Starting Go WebSocket server on port %s\n", port)
+
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("Server error: %v\n", err)
+			serverErrChan <- err
+		}
+	}()
+
+	// For CI environments, run for a maximum time instead of waiting indefinitely
+	maxRunTime := 5 * time.Minute
+	timeoutChan := time.After(maxRunTime)
+
+	select {
+	case <-sigChan:
+		fmt.Println("Received shutdown signal")
+	case <-timeoutChan:
+		fmt.Println("Maximum run time reached, shutting down")
+	case err := <-serverErrChan:
+		log.Fatalf("Server error: %v\n", err)
+	}
+
+	fmt.Println("Server shutting down...")
+
+	// Hijacked connections are not tracked by Shutdown, so this only waits
+	// for regular HTTP requests
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("Server shutdown error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("Server stopped gracefully")
+}
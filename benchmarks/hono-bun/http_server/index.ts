@@ -24,7 +24,9 @@ const server = serve({
 console.log(`Starting Hono.js HTTP server on Bun runtime on port ${server.port}`);
 
 // Handle graceful shutdown
-process.on('SIGINT', () => {
+const shutdown = () => {
   console.log('Server shutting down...');
   process.exit(0);
-}); 
\ No newline at end of file
+};
+process.on('SIGINT', shutdown);
+process.on('SIGTERM', shutdown); 
\ No newline at end of file
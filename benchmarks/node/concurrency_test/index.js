@@ -14,19 +14,28 @@ const args = process.argv.slice(2).reduce((acc, arg) => {
 const MODE = args.mode || 'single';
 const WORKLOAD = parseInt(args.workload || '1000000', 10);
 
+// XOR every hash into acc, so the digest does not depend on how the work was split
+function hashRange(start, end) {
+  const acc = Buffer.alloc(32);
+  for (let i = start; i < end; i++) {
+    const data = `data_${i}`;
+    const hash = crypto.createHash('sha256').update(data).digest();
+    for (let b = 0; b < 32; b++) {
+      acc[b] ^= hash[b];
+    }
+  }
+  return acc;
+}
+
 if (isMainThread) {
   // Main thread
   async function run() {
     const startTime = process.hrtime.bigint();
+    let digest = Buffer.alloc(32);
 
     if (MODE === 'single') {
       // Single-threaded execution
-      for (let i = 0; i < WORKLOAD; i++) {
-        const data = `data_${i}`;
-        const hash = crypto.createHash('sha256').update(data).digest('hex');
-        // Prevent optimization
-        if (hash.length === 0) break;
-      }
+      digest = hashRange(0, WORKLOAD);
     } else if (MODE === 'multi') {
       // Multi-threaded execution
       const numCPU = os.cpus().length;
@@ -50,7 +59,12 @@ if (isMainThread) {
         }));
       }
       
-      await Promise.all(workers);
+      const partials = await Promise.all(workers);
+      for (const partial of partials) {
+        for (let b = 0; b < 32; b++) {
+          digest[b] ^= partial[b];
+        }
+      }
     } else {
       console.error(`Invalid mode: ${MODE}. Use 'single' or 'multi'`);
       process.exit(1);
@@ -66,6 +80,7 @@ if (isMainThread) {
       totalTimeMs: totalTimeMs,
       operationsPerSecond: opsPerSecond,
       mode: MODE,
+      digest: digest.toString('hex'),
     }));
   }
 
@@ -76,13 +91,6 @@ if (isMainThread) {
 } else {
   // Worker thread
   const { start, end } = workerData;
-  
-  for (let i = start; i < end; i++) {
-    const data = `data_${i}`;
-    const hash = crypto.createHash('sha256').update(data).digest('hex');
-    // Prevent optimization
-    if (hash.length === 0) break;
-  }
-  
-  parentPort.postMessage('done');
+
+  parentPort.postMessage(hashRange(start, end));
 } 
\ No newline at end of file
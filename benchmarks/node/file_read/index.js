@@ -1,3 +1,4 @@
+const crypto = require('crypto');
 const fs = require('fs/promises');
 const path = require('path');
 
@@ -12,12 +13,24 @@ const args = process.argv.slice(2).reduce((acc, arg) => {
 
 const FILE_PATH = args.file || path.join(__dirname, '../../../test_data/medium.txt');
 const ITERATIONS = parseInt(args.iterations || '1000', 10);
+const CACHE = args.cache || 'warm';
+
+// Node.js has no posix_fadvise or aligned buffers, so only warm cached reads are supported
+if (CACHE !== 'warm' || args.direct === 'true') {
+  console.error('Cold cache and direct I/O reads are not supported by the Node.js benchmark');
+  process.exit(1);
+}
 
 async function run() {
+  // The digest hashes the bytes read in the first iteration
+  const hash = crypto.createHash('sha256');
   const startTime = process.hrtime.bigint();
 
   for (let i = 0; i < ITERATIONS; i++) {
-    await fs.readFile(FILE_PATH);
+    const data = await fs.readFile(FILE_PATH);
+    if (i === 0) {
+      hash.update(data);
+    }
   }
 
   const endTime = process.hrtime.bigint();
@@ -29,6 +42,9 @@ async function run() {
     operations: ITERATIONS,
     totalTimeMs: totalTimeMs,
     operationsPerSecond: opsPerSecond,
+    digest: hash.digest('hex'),
+    direct: false,
+    cache: CACHE,
   }));
 }
 
@@ -1,5 +1,5 @@
+const crypto = require('crypto');
 const fs = require('fs');
-const readline = require('readline');
 const path = require('path');
 
 // Simple arg parser
@@ -13,36 +13,65 @@ const args = process.argv.slice(2).reduce((acc, arg) => {
 
 const FILE_PATH = args.file || path.join(__dirname, '../../../test_data/xlarge_lines.txt');
 const ITERATIONS = parseInt(args.iterations || '10', 10);
+const CACHE = args.cache || 'warm';
 
-async function readFileLines(filePath) {
-  return new Promise((resolve, reject) => {
-    const fileStream = fs.createReadStream(filePath);
-    const rl = readline.createInterface({
-      input: fileStream,
-      crlfDelay: Infinity
-    });
+// Node.js has no posix_fadvise or aligned buffers, so only warm cached reads are supported
+if (CACHE !== 'warm' || args.direct === 'true') {
+  console.error('Cold cache and direct I/O reads are not supported by the Node.js benchmark');
+  process.exit(1);
+}
 
-    let lineCount = 0;
-    rl.on('line', (line) => {
-      lineCount++;
-    });
+// Every technology splits on \n, drops a trailing \r and skips empty lines
+function* splitLines(text) {
+  for (let line of text.split('\n')) {
+    if (line.endsWith('\r')) {
+      line = line.slice(0, -1);
+    }
+    if (line.length > 0) {
+      yield line;
+    }
+  }
+}
 
-    rl.on('close', () => {
-      resolve(lineCount);
-    });
+// countLines counts the lines of text, feeding each to hash followed by a
+// newline when hash is given
+function countLines(text, hash) {
+  let count = 0;
+  for (const line of splitLines(text)) {
+    if (hash) {
+      hash.update(line + '\n');
+    }
+    count++;
+  }
+  return count;
+}
 
-    rl.on('error', (err) => {
-      reject(err);
-    });
-  });
+// readFileLines splits complete lines as chunks arrive, without holding the
+// file in memory
+async function readFileLines(filePath, hash) {
+  let count = 0;
+  let rest = '';
+  for await (const chunk of fs.createReadStream(filePath, { encoding: 'utf8' })) {
+    const text = rest + chunk;
+    const end = text.lastIndexOf('\n');
+    if (end === -1) {
+      rest = text;
+      continue;
+    }
+    count += countLines(text.slice(0, end), hash);
+    rest = text.slice(end + 1);
+  }
+  return count + countLines(rest, hash);
 }
 
 async function run() {
+  // The digest hashes the lines read in the first iteration
+  const hash = crypto.createHash('sha256');
   const startTime = process.hrtime.bigint();
   let totalLines = 0;
 
   for (let i = 0; i < ITERATIONS; i++) {
-    const lineCount = await readFileLines(FILE_PATH);
+    const lineCount = await readFileLines(FILE_PATH, i === 0 ? hash : undefined);
     if (i === 0) {
       totalLines = lineCount;
     }
@@ -58,6 +87,9 @@ async function run() {
     totalTimeMs: totalTimeMs,
     operationsPerSecond: opsPerSecond,
     linesPerFile: totalLines,
+    digest: hash.digest('hex'),
+    direct: false,
+    cache: CACHE,
   }));
 }
 
@@ -1,4 +1,6 @@
+const crypto = require('crypto');
 const fs = require('fs/promises');
+const { constants: fsConstants } = require('fs');
 const path = require('path');
 
 // Simple arg parser
@@ -13,6 +15,38 @@ const args = process.argv.slice(2).reduce((acc, arg) => {
 const OUTPUT_PATH = args.output || path.join(__dirname, '../../../test_data/temp_output_lines.txt');
 const ITERATIONS = parseInt(args.iterations || '10', 10);
 const LINE_COUNT = parseInt(args.lines || '1000000', 10);
+const SYNC = args.sync || 'none';
+
+if (!['none', 'fsync', 'fdatasync', 'osync'].includes(SYNC)) {
+  console.error(`Invalid sync mode: ${SYNC}. Use 'none', 'fsync', 'fdatasync' or 'osync'`);
+  process.exit(1);
+}
+if (args.direct === 'true') {
+  console.error('Direct I/O is not supported by the Node.js benchmark');
+  process.exit(1);
+}
+
+// Write data to filePath and make it durable according to SYNC
+async function writeOutput(filePath, data) {
+  if (SYNC === 'none') {
+    await fs.writeFile(filePath, data);
+    return;
+  }
+
+  const { O_WRONLY, O_CREAT, O_TRUNC, O_SYNC } = fsConstants;
+  const flags = SYNC === 'osync' ? O_WRONLY | O_CREAT | O_TRUNC | O_SYNC : 'w';
+  const handle = await fs.open(filePath, flags);
+  try {
+    await handle.writeFile(data);
+    if (SYNC === 'fsync') {
+      await handle.sync();
+    } else if (SYNC === 'fdatasync') {
+      await handle.datasync();
+    }
+  } finally {
+    await handle.close();
+  }
+}
 
 const sampleTexts = [
   "Lorem ipsum dolor sit amet, consectetur adipiscing elit.",
@@ -22,6 +56,11 @@ const sampleTexts = [
   "Excepteur sint occaecat cupidatat non proident, sunt in culpa.",
 ];
 
+// Hex SHA-256 of a file, compared across technologies by the runner
+async function fileDigest(filePath) {
+  return crypto.createHash('sha256').update(await fs.readFile(filePath)).digest('hex');
+}
+
 async function run() {
   const startTime = process.hrtime.bigint();
 
@@ -34,13 +73,17 @@ async function run() {
       content += `Line ${lineNumber}: ${sampleTexts[textIndex]}\n`;
     }
 
-    await fs.writeFile(OUTPUT_PATH, content);
+    await writeOutput(OUTPUT_PATH, content);
   }
 
   const endTime = process.hrtime.bigint();
   const totalTimeMs = Number(endTime - startTime) / 1_000_000;
   const opsPerSecond = (ITERATIONS / totalTimeMs) * 1000;
 
+  // Read the output back before cleaning up so the digest covers what
+  // actually reached the file
+  const digest = await fileDigest(OUTPUT_PATH);
+
   // Clean up temp file
   try {
     await fs.unlink(OUTPUT_PATH);
@@ -54,6 +97,9 @@ async function run() {
     totalTimeMs: totalTimeMs,
     operationsPerSecond: opsPerSecond,
     linesPerIteration: LINE_COUNT,
+    digest: digest,
+    direct: false,
+    sync: SYNC,
   }));
 }
 
@@ -26,9 +26,11 @@ server.listen(port, () => {
 });
 
 // Handle graceful shutdown
-process.on('SIGINT', () => {
+const shutdown = () => {
   console.log('Server shutting down...');
   server.close(() => {
     process.exit(0);
   });
-}); 
\ No newline at end of file
+};
+process.on('SIGINT', shutdown);
+process.on('SIGTERM', shutdown); 
\ No newline at end of file
@@ -0,0 +1,192 @@
+const crypto = require('crypto');
+const fs = require('fs');
+const path = require('path');
+
+// Simple arg parser
+const args = process.argv.slice(2).reduce((acc, arg) => {
+  const [key, value] = arg.split('=');
+  if (key && value) {
+    acc[key.substring(2)] = value;
+  }
+  return acc;
+}, {});
+
+const SHAPE = args.shape || 'flat';
+const DECODE_MODE = args['decode-mode'] || 'untyped';
+const ITERATIONS = parseInt(args.iterations || '10', 10);
+
+// Typed records copy exactly the fields of the Go structs for each shape,
+// the way decoding into a struct drops everything else
+const pick = (o, ...keys) => Object.fromEntries(keys.map((key) => [key, o?.[key]]));
+const shapes = {
+  flat: (o) => pick(o, 'id', 'name', 'email', 'active', 'score', 'age', 'country', 'category', 'created_at'),
+  nested: (o) => ({
+    id: o.id,
+    user: {
+      id: o.user?.id,
+      name: o.user?.name,
+      address: {
+        ...pick(o.user?.address, 'street', 'city', 'zip'),
+        geo: pick(o.user?.address?.geo, 'lat', 'lng'),
+      },
+    },
+    order: {
+      items: (o.order?.items || []).map((item) => ({
+        ...pick(item, 'sku', 'quantity', 'price'),
+        attributes: pick(item.attributes, 'color', 'size'),
+      })),
+      totals: pick(o.order?.totals, 'subtotal', 'tax', 'total'),
+    },
+    meta: {
+      tags: o.meta?.tags,
+      history: (o.meta?.history || []).map((event) => pick(event, 'timestamp', 'event')),
+    },
+  }),
+  numeric: (o) => ({ ...pick(o, 'id', 'values', 'counts'), stats: pick(o.stats, 'min', 'max', 'mean', 'stddev') }),
+  string: (o) => pick(o, 'id', 'title', 'body', 'author', 'url', 'tags'),
+};
+
+if (!shapes[SHAPE]) {
+  console.error(`Invalid shape: ${SHAPE}. Use 'flat', 'nested', 'numeric' or 'string'`);
+  process.exit(1);
+}
+if (!['typed', 'untyped', 'streaming'].includes(DECODE_MODE)) {
+  console.error(`Invalid decode mode: ${DECODE_MODE}. Use 'typed', 'untyped' or 'streaming'`);
+  process.exit(1);
+}
+
+const FILE_PATH = args.file || path.join(__dirname, '../../../test_data', `shape_${SHAPE}.json`);
+
+// arrayElements yields the source text of every element of a JSON array
+// document, so each one can be parsed on its own without materializing the
+// whole array
+function* arrayElements(text) {
+  let i = skipWhitespace(text, 0);
+  if (text[i] !== '[') {
+    throw new Error('expected a JSON array');
+  }
+  i = skipWhitespace(text, i + 1);
+  if (text[i] === ']') {
+    return;
+  }
+
+  for (;;) {
+    const start = i;
+    let depth = 0;
+    let inString = false;
+    for (; i < text.length; i++) {
+      const c = text[i];
+      if (inString) {
+        if (c === '\\') {
+          i++;
+        } else if (c === '"') {
+          inString = false;
+        }
+      } else if (c === '"') {
+        inString = true;
+      } else if (c === '{' || c === '[') {
+        depth++;
+      } else if (c === '}' || c === ']') {
+        if (depth === 0) {
+          break;
+        }
+        depth--;
+      } else if (c === ',' && depth === 0) {
+        break;
+      }
+    }
+    if (i >= text.length) {
+      throw new Error('unexpected end of JSON array');
+    }
+    yield text.slice(start, i);
+
+    if (text[i] === ']') {
+      return;
+    }
+    i = skipWhitespace(text, i + 1);
+  }
+}
+
+function skipWhitespace(text, i) {
+  while (i < text.length && /\s/.test(text[i])) {
+    i++;
+  }
+  return i;
+}
+
+// parseDocument decodes the document and returns how many records it held.
+// When hash is given the id of every decoded record is fed to it.
+function parseDocument(text, hash) {
+  let records;
+  switch (DECODE_MODE) {
+    case 'typed':
+      records = JSON.parse(text).map(shapes[SHAPE]);
+      break;
+    case 'untyped':
+      records = JSON.parse(text);
+      if (!Array.isArray(records)) {
+        return 1;
+      }
+      break;
+    case 'streaming': {
+      let count = 0;
+      for (const element of arrayElements(text)) {
+        const record = shapes[SHAPE](JSON.parse(element));
+        if (hash) {
+          hash.update(`${record.id}\n`);
+        }
+        count++;
+      }
+      return count;
+    }
+  }
+  if (hash) {
+    for (const record of records) {
+      hash.update(`${record?.id ?? 0}\n`);
+    }
+  }
+  return records.length;
+}
+
+function run() {
+  if (!fs.existsSync(FILE_PATH)) {
+    console.error(`File not found: ${FILE_PATH}`);
+    process.exit(1);
+  }
+
+  // Load the document once so every mode parses from memory
+  const data = fs.readFileSync(FILE_PATH);
+  const text = data.toString('utf8');
+
+  // The digest hashes the ids decoded in the first iteration
+  const hash = crypto.createHash('sha256');
+  const startTime = process.hrtime.bigint();
+  let recordCount = 0;
+
+  for (let i = 0; i < ITERATIONS; i++) {
+    recordCount = parseDocument(text, i === 0 ? hash : undefined);
+  }
+
+  const endTime = process.hrtime.bigint();
+  const totalTimeMs = Number(endTime - startTime) / 1_000_000;
+  const opsPerSecond = (ITERATIONS / totalTimeMs) * 1000;
+
+  // Output JSON result to stdout
+  console.log(JSON.stringify({
+    operations: ITERATIONS,
+    totalTimeMs: totalTimeMs,
+    operationsPerSecond: opsPerSecond,
+    records: recordCount,
+    bytes: data.length,
+    decodeMode: DECODE_MODE,
+    shape: SHAPE,
+    digest: hash.digest('hex'),
+  }));
+}
+
+try {
+  run();
+} catch (err) {
+  console.error(`Error parsing JSON: ${err.message}`);
+  process.exit(1);
+}
@@ -1,5 +1,5 @@
+const crypto = require('crypto');
 const fs = require('fs');
-const readline = require('readline');
 const path = require('path');
 
 // Simple arg parser
@@ -11,46 +11,120 @@ const args = process.argv.slice(2).reduce((acc, arg) => {
   return acc;
 }, {});
 
-const FILE_PATH = args.file || path.join(__dirname, '../../../test_data/xlarge_lines.json');
+const SHAPE = args.shape || 'items';
+const DECODE_MODE = args['decode-mode'] || 'untyped';
 const ITERATIONS = parseInt(args.iterations || '10', 10);
 
-async function readJsonLines(filePath) {
-  return new Promise((resolve, reject) => {
-    const fileStream = fs.createReadStream(filePath);
-    const rl = readline.createInterface({
-      input: fileStream,
-      crlfDelay: Infinity
-    });
+// Typed records copy exactly the fields of the Go structs for each shape,
+// the way decoding into a struct drops everything else
+const pick = (o, ...keys) => Object.fromEntries(keys.map((key) => [key, o?.[key]]));
+const shapes = {
+  items: (o) => pick(o, 'id', 'name', 'description', 'category', 'priority', 'tags', 'timestamp', 'value', 'active'),
+  flat: (o) => pick(o, 'id', 'name', 'email', 'active', 'score', 'age', 'country', 'category', 'created_at'),
+  nested: (o) => ({
+    id: o.id,
+    user: {
+      id: o.user?.id,
+      name: o.user?.name,
+      address: {
+        ...pick(o.user?.address, 'street', 'city', 'zip'),
+        geo: pick(o.user?.address?.geo, 'lat', 'lng'),
+      },
+    },
+    order: {
+      items: (o.order?.items || []).map((item) => ({
+        ...pick(item, 'sku', 'quantity', 'price'),
+        attributes: pick(item.attributes, 'color', 'size'),
+      })),
+      totals: pick(o.order?.totals, 'subtotal', 'tax', 'total'),
+    },
+    meta: {
+      tags: o.meta?.tags,
+      history: (o.meta?.history || []).map((event) => pick(event, 'timestamp', 'event')),
+    },
+  }),
+  numeric: (o) => ({ ...pick(o, 'id', 'values', 'counts'), stats: pick(o.stats, 'min', 'max', 'mean', 'stddev') }),
+  string: (o) => pick(o, 'id', 'title', 'body', 'author', 'url', 'tags'),
+};
 
-    let lineCount = 0;
-    rl.on('line', (line) => {
-      if (line.trim()) {
-        try {
-          JSON.parse(line);
-          lineCount++;
-        } catch (err) {
-          reject(new Error(`Error parsing JSON line ${lineCount + 1}: ${err.message}`));
-          return;
-        }
-      }
-    });
+if (!shapes[SHAPE]) {
+  console.error(`Invalid shape: ${SHAPE}. Use 'items', 'flat', 'nested', 'numeric' or 'string'`);
+  process.exit(1);
+}
+if (!['typed', 'untyped', 'streaming'].includes(DECODE_MODE)) {
+  console.error(`Invalid decode mode: ${DECODE_MODE}. Use 'typed', 'untyped' or 'streaming'`);
+  process.exit(1);
+}
 
-    rl.on('close', () => {
-      resolve(lineCount);
-    });
+const defaultFile = SHAPE === 'items' ? 'xlarge_lines.json' : `shape_${SHAPE}_lines.json`;
+const FILE_PATH = args.file || path.join(__dirname, '../../../test_data', defaultFile);
 
-    rl.on('error', (err) => {
-      reject(err);
-    });
-  });
+// Every technology splits on \n, drops a trailing \r and skips empty lines
+function* splitLines(text) {
+  for (let line of text.split('\n')) {
+    if (line.endsWith('\r')) {
+      line = line.slice(0, -1);
+    }
+    if (line.length > 0) {
+      yield line;
+    }
+  }
+}
+
+// parseLines decodes every line and returns how many there were. When hash is
+// given the id of every parsed record is fed to it.
+function parseLines(lines, decode, hash, offset) {
+  let count = 0;
+  for (const line of lines) {
+    let record;
+    try {
+      record = decode(JSON.parse(line));
+    } catch (err) {
+      throw new Error(`Error parsing JSON line ${offset + count + 1}: ${err.message}`);
+    }
+    if (hash) {
+      hash.update(`${record.id}\n`);
+    }
+    count++;
+  }
+  return count;
+}
+
+// readWhole reads the file into memory and splits it into lines first
+async function readWhole(filePath, decode, hash) {
+  const text = await fs.promises.readFile(filePath, 'utf8');
+  return parseLines(splitLines(text), decode, hash, 0);
+}
+
+// readStreaming parses complete lines as chunks arrive, without holding the
+// file in memory
+async function readStreaming(filePath, decode, hash) {
+  let count = 0;
+  let rest = '';
+  for await (const chunk of fs.createReadStream(filePath, { encoding: 'utf8' })) {
+    const text = rest + chunk;
+    const end = text.lastIndexOf('\n');
+    if (end === -1) {
+      rest = text;
+      continue;
+    }
+    count += parseLines(splitLines(text.slice(0, end)), decode, hash, count);
+    rest = text.slice(end + 1);
+  }
+  return count + parseLines(splitLines(rest), decode, hash, count);
 }
 
 async function run() {
+  const decode = DECODE_MODE === 'typed' ? shapes[SHAPE] : (record) => record;
+  const read = DECODE_MODE === 'streaming' ? readStreaming : readWhole;
+
+  // The digest hashes the ids parsed in the first iteration
+  const hash = crypto.createHash('sha256');
   const startTime = process.hrtime.bigint();
   let totalLines = 0;
 
   for (let i = 0; i < ITERATIONS; i++) {
-    const lineCount = await readJsonLines(FILE_PATH);
+    const lineCount = await read(FILE_PATH, decode, i === 0 ? hash : undefined);
     if (i === 0) {
       totalLines = lineCount;
     }
@@ -66,10 +140,13 @@ async function run() {
     totalTimeMs: totalTimeMs,
     operationsPerSecond: opsPerSecond,
     linesPerFile: totalLines,
+    decodeMode: DECODE_MODE,
+    shape: SHAPE,
+    digest: hash.digest('hex'),
   }));
 }
 
 run().catch(err => {
   console.error(err);
   process.exit(1);
-}); 
\ No newline at end of file
+});
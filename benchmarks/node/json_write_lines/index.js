@@ -1,5 +1,8 @@
+const crypto = require('crypto');
 const fs = require('fs/promises');
 const path = require('path');
+const { createWriteStream } = require('fs');
+const { once } = require('events');
 
 // Simple arg parser
 const args = process.argv.slice(2).reduce((acc, arg) => {
@@ -13,6 +16,12 @@ const args = process.argv.slice(2).reduce((acc, arg) => {
 const OUTPUT_PATH = args.output || path.join(__dirname, '../../../test_data/temp_output_lines.json');
 const ITERATIONS = parseInt(args.iterations || '10', 10);
 const LINE_COUNT = parseInt(args.lines || '1000000', 10);
+const DECODE_MODE = args['decode-mode'] || 'typed';
+
+if (!['typed', 'untyped', 'streaming'].includes(DECODE_MODE)) {
+  console.error(`Invalid decode mode: ${DECODE_MODE}. Use 'typed', 'untyped' or 'streaming'`);
+  process.exit(1);
+}
 
 const sampleCategories = ["test", "benchmark", "data", "sample", "mock"];
 const samplePriorities = ["low", "medium", "high", "critical"];
@@ -24,26 +33,71 @@ const sampleTags = [
   ["json", "test"]
 ];
 
+// Hex SHA-256 of a file, compared across technologies by the runner
+async function fileDigest(filePath) {
+  return crypto.createHash('sha256').update(await fs.readFile(filePath)).digest('hex');
+}
+
+// Timestamps are derived from a fixed base time so every technology writes
+// identical lines
+const BASE_TIME = 1700000000 * 1000;
+
+// makeItem builds line j with its fields in the order of the Go struct
+function makeItem(j) {
+  return {
+    id: j + 1,
+    name: `Item_${String(j + 1).padStart(7, '0')}`,
+    description: `Sample item ${j + 1} for benchmarking JSON line operations`,
+    category: sampleCategories[j % sampleCategories.length],
+    priority: samplePriorities[j % samplePriorities.length],
+    tags: sampleTags[j % sampleTags.length],
+    timestamp: new Date(BASE_TIME + j * 1000).toISOString(),
+    value: j * 100,
+    active: j % 3 === 0
+  };
+}
+
+// untypedItem copies an item into a plain object with its keys sorted like
+// Go's map encoding
+function untypedItem(item) {
+  return {
+    active: item.active,
+    category: item.category,
+    description: item.description,
+    id: item.id,
+    name: item.name,
+    priority: item.priority,
+    tags: item.tags,
+    timestamp: item.timestamp,
+    value: item.value,
+  };
+}
+
+// writeStreaming writes the lines one at a time through a write stream
+async function writeStreaming(filePath) {
+  const stream = createWriteStream(filePath);
+  for (let j = 0; j < LINE_COUNT; j++) {
+    if (!stream.write(JSON.stringify(makeItem(j)) + '\n')) {
+      await once(stream, 'drain');
+    }
+  }
+  stream.end();
+  await once(stream, 'finish');
+}
+
 async function run() {
   const startTime = process.hrtime.bigint();
 
   for (let i = 0; i < ITERATIONS; i++) {
+    if (DECODE_MODE === 'streaming') {
+      await writeStreaming(OUTPUT_PATH);
+      continue;
+    }
+
     let content = '';
-    
     for (let j = 0; j < LINE_COUNT; j++) {
-      const item = {
-        id: j + 1,
-        name: `Item_${String(j + 1).padStart(7, '0')}`,
-        description: `Sample item ${j + 1} for benchmarking JSON line operations`,
-        category: sampleCategories[j % sampleCategories.length],
-        priority: samplePriorities[j % samplePriorities.length],
-        tags: sampleTags[j % sampleTags.length],
-        timestamp: new Date(Date.now() + j * 1000).toISOString(),
-        value: j * 100,
-        active: j % 3 === 0
-      };
-      
-      content += JSON.stringify(item) + '\n';
+      const item = makeItem(j);
+      content += JSON.stringify(DECODE_MODE === 'typed' ? item : untypedItem(item)) + '\n';
     }
 
     await fs.writeFile(OUTPUT_PATH, content);
@@ -53,6 +107,10 @@ async function run() {
   const totalTimeMs = Number(endTime - startTime) / 1_000_000;
   const opsPerSecond = (ITERATIONS / totalTimeMs) * 1000;
 
+  // Read the output back before cleaning up so the digest covers what
+  // actually reached the file
+  const digest = await fileDigest(OUTPUT_PATH);
+
   // Clean up temp file
   try {
     await fs.unlink(OUTPUT_PATH);
@@ -66,6 +124,8 @@ async function run() {
     totalTimeMs: totalTimeMs,
     operationsPerSecond: opsPerSecond,
     linesPerIteration: LINE_COUNT,
+    decodeMode: DECODE_MODE,
+    digest: digest,
   }));
 }
 
@@ -0,0 +1,3 @@
+module performance-benchmark-suite
+
+go 1.21
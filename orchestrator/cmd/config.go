@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"performance-benchmark-suite/orchestrator/config"
+	"performance-benchmark-suite/orchestrator/runner"
+
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the benchmark configuration",
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the configuration for mistakes",
+	Long: `Load the configuration selected with --config and check it: unknown or
+duplicate keys, values of the wrong type, missing required fields, unknown
+benchmark types, port mismatches and ports used twice, parameters the
+benchmark does not read or cannot parse, and commands that refer to missing
+script files. Every problem is reported with the file, line and column that
+defines it. Diagnostics are printed to stderr.
+
+The same checks run before every command; errors stop it, warnings do not.
+
+Examples:
+  benchmark-cli config validate
+  benchmark-cli config validate --config config --config ~/bench-local.yaml`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadConfig(configPaths...)
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %v", err)
+		}
+
+		errors, warnings := printDiagnostics(cfg.Validate(runner.BenchmarkTypes()))
+		fmt.Printf("%d files checked: %d errors, %d warnings\n", len(cfg.Files), errors, warnings)
+		if errors > 0 {
+			return fmt.Errorf("configuration has %d errors", errors)
+		}
+		return nil
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configValidateCmd)
+}
+
+// printDiagnostics prints each diagnostic on its own line to stderr, keeping
+// stdout for the command's own output, and counts them.
+func printDiagnostics(diagnostics []config.Diagnostic) (errors, warnings int) {
+	for _, d := range diagnostics {
+		fmt.Fprintln(os.Stderr, d)
+		if d.Warning {
+			warnings++
+		} else {
+			errors++
+		}
+	}
+	return errors, warnings
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+
+	"performance-benchmark-suite/orchestrator/data"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	dataDir      string
+	dataSeed     int64
+	dataDatasets string
+)
+
+var dataCmd = &cobra.Command{
+	Use:   "data",
+	Short: "Manage benchmark test data",
+}
+
+var dataGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate test data and its manifest",
+	Long: `Generate the test data files the benchmarks read. Generation is deterministic:
+the same seed always produces byte-identical files. Sizes and SHA-256 sums are
+written to manifest.json in the data directory.
+
+Examples:
+  benchmark-cli data generate
+  benchmark-cli data generate --only=xlarge_lines.txt,xlarge_lines.json
+  benchmark-cli data generate --seed=7 --dir=/tmp/test_data`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names, err := selectDatasets(dataDatasets)
+		if err != nil {
+			return err
+		}
+
+		manifest, err := data.Generate(dataDir, names, dataSeed)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("\nGenerated %d datasets with seed %d in %s\n", len(names), manifest.Seed, dataDir)
+		return nil
+	},
+}
+
+var dataVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check test data against the manifest",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names, err := selectDatasets(dataDatasets)
+		if err != nil {
+			return err
+		}
+
+		manifest, err := data.LoadManifest(dataDir)
+		if err != nil {
+			return err
+		}
+		if manifest == nil {
+			return fmt.Errorf("no %s in %s", data.ManifestFile, dataDir)
+		}
+
+		failures := 0
+		for _, name := range names {
+			if err := data.Verify(dataDir, manifest, name); err != nil {
+				fmt.Printf("  ✗ %v\n", err)
+				failures++
+				continue
+			}
+			fmt.Printf("  ✓ %s\n", name)
+		}
+		if failures > 0 {
+			return fmt.Errorf("%d of %d datasets failed verification", failures, len(names))
+		}
+		return nil
+	},
+}
+
+func init() {
+	dataCmd.PersistentFlags().StringVar(&dataDir, "dir", "test_data", "Test data directory")
+	dataCmd.PersistentFlags().StringVar(&dataDatasets, "only", "all", "Comma-separated list of datasets (use 'all' for every dataset)")
+	dataGenerateCmd.Flags().Int64Var(&dataSeed, "seed", data.DefaultSeed, "Seed for generated values")
+
+	dataCmd.AddCommand(dataGenerateCmd)
+	dataCmd.AddCommand(dataVerifyCmd)
+}
+
+func selectDatasets(input string) ([]string, error) {
+	names := parseList(input)
+	for _, name := range names {
+		if name == "all" {
+			return data.Names(), nil
+		}
+		if _, ok := data.Lookup(name); !ok {
+			return nil, fmt.Errorf("unknown dataset: %s (available: %v)", name, data.Names())
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no datasets specified")
+	}
+	return removeDuplicates(names), nil
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"performance-benchmark-suite/orchestrator/runner"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorTechs   string
+	doctorTests   string
+	doctorOutput  string
+	doctorWorkdir string
+	doctorFormat  string
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that the environment is ready to run benchmarks",
+	Long: `Check everything a run needs before starting it: the commands the selected
+benchmarks and version commands use, wrk for HTTP server benchmarks, test data,
+free ports, writable report and workspace directories with enough disk space,
+and environment signals that make results noisy (CPU governor, load, swap).
+
+Failures make the command exit non-zero; warnings do not.
+
+Examples:
+  benchmark-cli doctor
+  benchmark-cli doctor --tech=go,node --test=file_read,http_server
+  benchmark-cli doctor --workdir=/dev/shm --format=json`,
+	// Failed checks are not usage mistakes, and main reports the error
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if doctorFormat != "table" && doctorFormat != "json" {
+			return fmt.Errorf("invalid format: %s (use 'table' or 'json')", doctorFormat)
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		techList, err := selectTechnologies(cfg, doctorTechs)
+		if err != nil {
+			return err
+		}
+		testList := selectTests(cfg, techList, doctorTests)
+
+		benchmarkRunner, err := runner.NewRunner(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to create runner: %v", err)
+		}
+		if doctorWorkdir != "" {
+			if err := benchmarkRunner.SetWorkdir(doctorWorkdir); err != nil {
+				return err
+			}
+		}
+
+		checks := benchmarkRunner.Doctor(techList, testList, doctorOutput)
+
+		counts := map[string]int{}
+		for _, check := range checks {
+			counts[check.Status]++
+		}
+
+		if doctorFormat == "json" {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(checks); err != nil {
+				return err
+			}
+		} else {
+			printChecks(checks)
+			fmt.Printf("\n%d passed, %d warnings, %d failed\n",
+				counts[runner.CheckPass], counts[runner.CheckWarn], counts[runner.CheckFail])
+		}
+
+		if counts[runner.CheckFail] > 0 {
+			return fmt.Errorf("%d preflight checks failed", counts[runner.CheckFail])
+		}
+		return nil
+	},
+}
+
+func init() {
+	doctorCmd.Flags().StringVarP(&doctorTechs, "tech", "t", "all", "Comma-separated list of technologies to check (use 'all' for all available)")
+	doctorCmd.Flags().StringVarP(&doctorTests, "test", "e", "all", "Comma-separated list of tests to check (use 'all' for all available)")
+	doctorCmd.Flags().StringVarP(&doctorOutput, "output-dir", "o", "./reports", "Directory reports will be saved to")
+	doctorCmd.Flags().StringVar(&doctorWorkdir, "workdir", "", "Directory for per-run benchmark workspaces (default: the system temp directory)")
+	doctorCmd.Flags().StringVar(&doctorFormat, "format", "table", "Output format: table or json")
+}
+
+func printChecks(checks []runner.Check) {
+	symbols := map[string]string{
+		runner.CheckPass: "✓",
+		runner.CheckWarn: "!",
+		runner.CheckFail: "✗",
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "  \tstatus\tcategory\tcheck\tdetail\n")
+	for _, check := range checks {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", symbols[check.Status], check.Status, check.Category, check.Name, check.Detail)
+	}
+	w.Flush()
+}
@@ -0,0 +1,272 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"performance-benchmark-suite/orchestrator/config"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	listTechs  string
+	listTests  string
+	listFormat string
+)
+
+// toolchain is whether a technology's version command can be run here.
+type toolchain struct {
+	Executable string `json:"executable"`
+	Path       string `json:"path,omitempty"`
+	Available  bool   `json:"available"`
+	Version    string `json:"version,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+type listTechnology struct {
+	Key       string    `json:"key"`
+	Name      string    `json:"name"`
+	Toolchain toolchain `json:"toolchain"`
+}
+
+type listBenchmark struct {
+	Tech          string              `json:"tech"`
+	Test          string              `json:"test"`
+	Supported     bool                `json:"supported"`
+	Type          string              `json:"type,omitempty"`
+	Command       []string            `json:"command,omitempty"`
+	DefaultParams map[string]string   `json:"defaultParams,omitempty"`
+	Sweep         map[string][]string `json:"sweep,omitempty"`
+}
+
+type listOutput struct {
+	Technologies []listTechnology        `json:"technologies"`
+	Tests        []string                `json:"tests"`
+	Benchmarks   []listBenchmark         `json:"benchmarks"`
+	Suites       map[string]config.Suite `json:"suites,omitempty"`
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show which technologies support which tests",
+	Long: `Show the matrix of technologies versus tests from the configuration, with the
+type and default parameters of every benchmark and whether each technology's
+toolchain is installed.
+
+Examples:
+  benchmark-cli list
+  benchmark-cli list --tech=go,node --test=file_read,http_server
+  benchmark-cli list --format=json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if listFormat != "table" && listFormat != "json" {
+			return fmt.Errorf("invalid format: %s (use 'table' or 'json')", listFormat)
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		techList, err := selectTechnologies(cfg, listTechs)
+		if err != nil {
+			return err
+		}
+		testList := selectTests(cfg, techList, listTests)
+
+		out := listOutput{Tests: testList, Suites: cfg.Suites}
+		for _, key := range techList {
+			tech := cfg.Technologies[key]
+			out.Technologies = append(out.Technologies, listTechnology{
+				Key:       key,
+				Name:      tech.Name,
+				Toolchain: detectToolchain(tech),
+			})
+		}
+		for _, test := range testList {
+			for _, key := range techList {
+				entry := listBenchmark{Tech: key, Test: test}
+				if benchmark, ok := cfg.Technologies[key].Benchmarks[test]; ok {
+					entry.Supported = true
+					entry.Type = benchmark.Type
+					entry.Command = benchmark.Command
+					entry.DefaultParams = benchmark.DefaultParams
+					entry.Sweep = benchmark.Sweep
+				}
+				out.Benchmarks = append(out.Benchmarks, entry)
+			}
+		}
+
+		if listFormat == "json" {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(out)
+		}
+		printList(out)
+		return nil
+	},
+}
+
+func init() {
+	listCmd.Flags().StringVarP(&listTechs, "tech", "t", "all", "Comma-separated list of technologies to show (use 'all' for all available)")
+	listCmd.Flags().StringVarP(&listTests, "test", "e", "all", "Comma-separated list of tests to show (use 'all' for all available)")
+	listCmd.Flags().StringVar(&listFormat, "format", "table", "Output format: table or json")
+}
+
+func printList(out listOutput) {
+	fmt.Printf("Technologies:\n")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, tech := range out.Technologies {
+		status := "✓ " + tech.Toolchain.Version
+		if !tech.Toolchain.Available {
+			status = "✗ " + tech.Toolchain.Error
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", tech.Key, tech.Name, tech.Toolchain.Executable, status)
+	}
+	w.Flush()
+
+	fmt.Printf("\nSupport matrix:\n")
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "  test")
+	for _, tech := range out.Technologies {
+		fmt.Fprintf(w, "\t%s", tech.Key)
+	}
+	fmt.Fprintf(w, "\n")
+	for i, test := range out.Tests {
+		fmt.Fprintf(w, "  %s", test)
+		for _, entry := range out.Benchmarks[i*len(out.Technologies) : (i+1)*len(out.Technologies)] {
+			cell := "-"
+			if entry.Supported {
+				cell = entry.Type
+			}
+			fmt.Fprintf(w, "\t%s", cell)
+		}
+		fmt.Fprintf(w, "\n")
+	}
+	w.Flush()
+
+	fmt.Printf("\nDefault parameters:\n")
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, entry := range out.Benchmarks {
+		if len(entry.DefaultParams) == 0 && len(entry.Sweep) == 0 {
+			continue
+		}
+		params := formatParams(entry.DefaultParams)
+		if len(entry.Sweep) > 0 {
+			params = strings.TrimSpace(params + " sweep " + formatSweep(entry.Sweep))
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", entry.Test, entry.Tech, params)
+	}
+	w.Flush()
+
+	if len(out.Suites) == 0 {
+		return
+	}
+	fmt.Printf("\nSuites:\n")
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	names := make([]string, 0, len(out.Suites))
+	for name := range out.Suites {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		suite := out.Suites[name]
+		techs, tests := "all", "all"
+		if len(suite.Technologies) > 0 {
+			techs = strings.Join(suite.Technologies, ",")
+		}
+		if len(suite.Tests) > 0 {
+			tests = strings.Join(suite.Tests, ",")
+		}
+		fmt.Fprintf(w, "  %s\t%s\ttech=%s\ttest=%s\n", name, suite.Description, techs, tests)
+	}
+	w.Flush()
+}
+
+// detectToolchain looks up the executable of tech's version command on PATH
+// and runs the command to get its version.
+func detectToolchain(tech config.Technology) toolchain {
+	if len(tech.VersionCommand) == 0 {
+		return toolchain{Error: "no version_command configured"}
+	}
+
+	tc := toolchain{Executable: tech.VersionCommand[0]}
+	path, err := exec.LookPath(tc.Executable)
+	if err != nil {
+		tc.Error = "not found on PATH"
+		return tc
+	}
+	tc.Path = path
+
+	output, err := exec.Command(path, tech.VersionCommand[1:]...).Output()
+	if err != nil {
+		tc.Error = fmt.Sprintf("version command failed: %v", err)
+		return tc
+	}
+	tc.Available = true
+	tc.Version = strings.TrimSpace(string(output))
+	return tc
+}
+
+// selectTechnologies resolves a --tech value to sorted technology keys.
+func selectTechnologies(cfg *config.Config, input string) ([]string, error) {
+	techList := removeDuplicates(parseList(input))
+	for _, tech := range techList {
+		if tech == "all" {
+			techList = cfg.ListTechnologies()
+			break
+		}
+		if !cfg.ValidateTechnology(tech) {
+			return nil, fmt.Errorf("invalid technology: %s", tech)
+		}
+	}
+	sort.Strings(techList)
+	return techList, nil
+}
+
+// selectTests resolves a --test value to sorted test names, where 'all' is
+// every test at least one of techList supports.
+func selectTests(cfg *config.Config, techList []string, input string) []string {
+	testList := parseList(input)
+	for _, test := range testList {
+		if test == "all" {
+			testList = []string{}
+			for _, tech := range techList {
+				if tests, err := cfg.ListBenchmarks(tech); err == nil {
+					testList = append(testList, tests...)
+				}
+			}
+			break
+		}
+	}
+	testList = removeDuplicates(testList)
+	sort.Strings(testList)
+	return testList
+}
+
+func formatParams(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+params[key])
+	}
+	return strings.Join(pairs, " ")
+}
+
+func formatSweep(sweep map[string][]string) string {
+	params := make(map[string]string, len(sweep))
+	for key, values := range sweep {
+		params[key] = strings.Join(values, ",")
+	}
+	return formatParams(params)
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+var (
+	logLevel  string
+	logFormat string
+)
+
+// setupLogging sends log records at --log-level and above to stderr, leaving
+// stdout to the command's progress view and output.
+func setupLogging() error {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(logLevel)); err != nil {
+		return fmt.Errorf("invalid log level: %s (use debug, info, warn or error)", logLevel)
+	}
+	handler, err := newLogHandler(os.Stderr, level)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+// openLogFile additionally writes every record, down to debug, to a log file
+// in dir. The returned function closes it.
+func openLogFile(dir string) (string, func() error, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", nil, fmt.Errorf("failed to create output directory: %v", err)
+	}
+	timestamp := time.Now().UTC().Format("2006-01-02T15-04-05Z")
+	path := filepath.Join(dir, fmt.Sprintf("run_%s.log", timestamp))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create log file: %v", err)
+	}
+
+	handler, err := newLogHandler(file, slog.LevelDebug)
+	if err != nil {
+		file.Close()
+		return "", nil, err
+	}
+	console := slog.Default().Handler()
+	slog.SetDefault(slog.New(teeHandler{console, handler}))
+	return path, func() error {
+		slog.SetDefault(slog.New(console))
+		return file.Close()
+	}, nil
+}
+
+func newLogHandler(w io.Writer, level slog.Level) (slog.Handler, error) {
+	options := &slog.HandlerOptions{Level: level}
+	switch logFormat {
+	case "text":
+		return slog.NewTextHandler(w, options), nil
+	case "json":
+		return slog.NewJSONHandler(w, options), nil
+	default:
+		return nil, fmt.Errorf("invalid log format: %s (use text or json)", logFormat)
+	}
+}
+
+// teeHandler passes each record to every handler that accepts its level.
+type teeHandler []slog.Handler
+
+func (t teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range t {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t teeHandler) Handle(ctx context.Context, record slog.Record) error {
+	var first error
+	for _, handler := range t {
+		if !handler.Enabled(ctx, record.Level) {
+			continue
+		}
+		if err := handler.Handle(ctx, record.Clone()); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+func (t teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make(teeHandler, len(t))
+	for i, handler := range t {
+		handlers[i] = handler.WithAttrs(attrs)
+	}
+	return handlers
+}
+
+func (t teeHandler) WithGroup(name string) slog.Handler {
+	handlers := make(teeHandler, len(t))
+	for i, handler := range t {
+		handlers[i] = handler.WithGroup(name)
+	}
+	return handlers
+}
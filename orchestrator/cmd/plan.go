@@ -0,0 +1,377 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"performance-benchmark-suite/orchestrator/config"
+	"performance-benchmark-suite/orchestrator/report"
+	"performance-benchmark-suite/orchestrator/runner"
+
+	"github.com/spf13/cobra"
+)
+
+var planFormat string
+
+// runPlan is what the selection flags resolve to: the trials in execution
+// order and the technology and test pairs left out because the technology
+// has no such benchmark.
+type runPlan struct {
+	suite       *config.Suite
+	techs       []string
+	tests       []string
+	trials      []runner.Trial
+	unsupported []runner.PlannedTrial
+	execution   *report.Execution
+}
+
+type planOutput struct {
+	Suite  string                `json:"suite,omitempty"`
+	Order  string                `json:"order"`
+	Seed   *int64                `json:"seed,omitempty"`
+	Runs   int                   `json:"runs"`
+	Trials []runner.PlannedTrial `json:"trials"`
+}
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show what a run would execute without running it",
+	Long: `Resolve the technologies, tests, suite, sweeps and parameters selected with the
+same flags as run, and show every trial in execution order: its default and
+overridden parameters, ports, workspace, test data, environment and command
+lines, or why it would be skipped. Nothing is started; 'run --dry-run' prints
+the same plan.
+
+Examples:
+  benchmark-cli plan --tech=go,node --test=file_write --sweep size=1024,65536
+  benchmark-cli plan --suite=nightly --runs=3 --order=random --seed=42
+  benchmark-cli plan --suite=quick --format=json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if planFormat != "table" && planFormat != "json" {
+			return fmt.Errorf("invalid format: %s (use 'table' or 'json')", planFormat)
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		plan, err := planTrials(cmd, cfg)
+		if err != nil {
+			return err
+		}
+		benchmarkRunner, err := newRunner(cfg)
+		if err != nil {
+			return err
+		}
+		return printPlan(benchmarkRunner, plan, planFormat)
+	},
+}
+
+func init() {
+	addSelectionFlags(planCmd)
+	planCmd.Flags().StringVar(&planFormat, "format", "table", "Output format: table or json")
+}
+
+// addSelectionFlags adds the flags that decide what a run executes, shared
+// by run and plan.
+func addSelectionFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&technologies, "tech", "t", "all", "Comma-separated list of technologies to test (use 'all' for all available)")
+	cmd.Flags().StringVarP(&tests, "test", "e", "all", "Comma-separated list of tests to run (use 'all' for all available)")
+	cmd.Flags().StringVarP(&outputDir, "output-dir", "o", "./reports", "Directory to save the report")
+	cmd.Flags().StringVar(&rpsDuration, "rps-duration", "15s", "Duration for RPS test")
+	cmd.Flags().IntVar(&rpsConnections, "rps-connections", 100, "Number of concurrent connections for RPS test")
+	cmd.Flags().StringVar(&suiteName, "suite", "", "Named suite from the configuration selecting technologies, tests and parameters")
+	cmd.Flags().StringArrayVarP(&extraParams, "param", "p", nil, "Benchmark parameter override as key=value (repeatable)")
+	cmd.Flags().StringArrayVar(&extraSweeps, "sweep", nil, "Run a test once per value as [test:]key=v1,v2,... (repeatable, combinations are multiplied)")
+	cmd.Flags().IntVar(&runCount, "runs", 1, "Number of times to run each benchmark")
+	cmd.Flags().StringVar(&runOrder, "order", runner.OrderSequential, "Execution order: sequential, interleave (rotate technologies per run) or random")
+	cmd.Flags().Int64Var(&runSeed, "seed", 0, "Seed for --order=random (default: derived from the current time and recorded in the report)")
+	cmd.Flags().StringVar(&workdir, "workdir", "", "Directory for per-run benchmark workspaces (default: the system temp directory)")
+	cmd.Flags().StringVar(&profileKinds, "profile", "", "Capture profiles: comma-separated cpu, heap and trace (--profile alone means cpu,heap)")
+	cmd.Flag("profile").NoOptDefVal = "cpu,heap"
+}
+
+// newRunner creates a runner with the workdir and profiling flags applied.
+func newRunner(cfg *config.Config) (*runner.Runner, error) {
+	benchmarkRunner, err := runner.NewRunner(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create runner: %v", err)
+	}
+	if workdir != "" {
+		if err := benchmarkRunner.SetWorkdir(workdir); err != nil {
+			return nil, err
+		}
+	}
+	if profileKinds != "" {
+		if _, err := benchmarkRunner.EnableProfiling(removeDuplicates(parseList(profileKinds)), outputDir); err != nil {
+			return nil, err
+		}
+	}
+	return benchmarkRunner, nil
+}
+
+// planTrials resolves the technologies, tests, suite, parameters and sweeps
+// selected on the command line into trials in execution order.
+func planTrials(cmd *cobra.Command, cfg *config.Config) (*runPlan, error) {
+	var err error
+	plan := &runPlan{suite: &config.Suite{}}
+
+	// A suite selects technologies and tests unless they are given explicitly
+	if suiteName != "" {
+		if plan.suite, err = cfg.GetSuite(suiteName); err != nil {
+			return nil, err
+		}
+		if !cmd.Flags().Changed("tech") && len(plan.suite.Technologies) > 0 {
+			technologies = strings.Join(plan.suite.Technologies, ",")
+		}
+		if !cmd.Flags().Changed("test") && len(plan.suite.Tests) > 0 {
+			tests = strings.Join(plan.suite.Tests, ",")
+		}
+	}
+	suite := plan.suite
+
+	// Parse technologies
+	techList := parseList(technologies)
+	if len(techList) == 0 {
+		return nil, fmt.Errorf("no technologies specified")
+	}
+
+	// Parse tests
+	testList := parseList(tests)
+	if len(testList) == 0 {
+		return nil, fmt.Errorf("no tests specified")
+	}
+
+	// Validate technologies
+	validTechs := cfg.ListTechnologies()
+	for _, tech := range techList {
+		if tech == "all" {
+			techList = validTechs
+			break
+		}
+		if !cfg.ValidateTechnology(tech) {
+			return nil, fmt.Errorf("invalid technology: %s", tech)
+		}
+	}
+
+	// Validate tests
+	for _, test := range testList {
+		if test == "all" {
+			// Get all tests from all technologies
+			testList = []string{}
+			for _, tech := range validTechs {
+				if tests, err := cfg.ListBenchmarks(tech); err == nil {
+					testList = append(testList, tests...)
+				}
+			}
+			break
+		}
+	}
+
+	overrides, err := parseParams(extraParams)
+	if err != nil {
+		return nil, err
+	}
+	sweeps, err := parseSweeps(extraSweeps)
+	if err != nil {
+		return nil, err
+	}
+	if runCount < 1 {
+		return nil, fmt.Errorf("invalid runs: %d (must be at least 1)", runCount)
+	}
+
+	// Remove duplicates and sort, so sequential order is reproducible
+	techList = removeDuplicates(techList)
+	testList = removeDuplicates(testList)
+	sort.Strings(techList)
+	sort.Strings(testList)
+	plan.techs, plan.tests = techList, testList
+
+	// Plan the trials technology by technology, then put them in order
+	for _, tech := range techList {
+		for _, test := range testList {
+			// Check if this technology supports this test
+			if !cfg.ValidateBenchmark(tech, test) {
+				plan.unsupported = append(plan.unsupported, runner.PlannedTrial{
+					Tech:       tech,
+					Test:       test,
+					Skip:       runner.SkipUnsupported,
+					SkipReason: fmt.Sprintf("%s has no %s benchmark", tech, test),
+				})
+				continue
+			}
+
+			// Suite parameters beat the --rps-* defaults but not explicit flags
+			params := make(map[string]string)
+			for key, value := range suite.Params[test] {
+				params[key] = value
+			}
+			if test == "http_server" {
+				if _, ok := params["duration"]; !ok || cmd.Flags().Changed("rps-duration") {
+					params["duration"] = rpsDuration
+				}
+				if _, ok := params["connections"]; !ok || cmd.Flags().Changed("rps-connections") {
+					params["connections"] = fmt.Sprintf("%d", rpsConnections)
+				}
+			}
+			for key, value := range overrides {
+				params[key] = value
+			}
+
+			benchmark, _ := cfg.GetBenchmark(tech, test)
+			sets := runner.SweepCombinations(resolveSweep(benchmark, suite, test, overrides, sweeps))
+			if len(sets) == 0 {
+				sets = []map[string]string{nil}
+			}
+
+			for _, set := range sets {
+				runParams := make(map[string]string, len(params)+len(set))
+				for key, value := range params {
+					runParams[key] = value
+				}
+				for key, value := range set {
+					runParams[key] = value
+				}
+				for run := 1; run <= runCount; run++ {
+					plan.trials = append(plan.trials, runner.Trial{Tech: tech, Test: test, Params: runParams, Sweep: set, Run: run})
+				}
+			}
+		}
+	}
+
+	plan.execution = &report.Execution{Order: runOrder, Runs: runCount}
+	if runOrder == runner.OrderRandom {
+		if !cmd.Flags().Changed("seed") {
+			runSeed = time.Now().UnixNano()
+		}
+		plan.execution.Seed = &runSeed
+	}
+	if plan.trials, err = runner.OrderTrials(plan.trials, runOrder, runSeed); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// printPlan resolves every trial of plan and prints the result.
+func printPlan(benchmarkRunner *runner.Runner, plan *runPlan, format string) error {
+	out := planOutput{
+		Suite: suiteName,
+		Order: plan.execution.Order,
+		Seed:  plan.execution.Seed,
+		Runs:  plan.execution.Runs,
+	}
+	for _, trial := range plan.trials {
+		out.Trials = append(out.Trials, benchmarkRunner.Plan(trial))
+	}
+	out.Trials = append(out.Trials, plan.unsupported...)
+
+	if format == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(out)
+	}
+
+	var skipped []runner.PlannedTrial
+	var planned []runner.PlannedTrial
+	for _, trial := range out.Trials {
+		if trial.Skip != "" {
+			skipped = append(skipped, trial)
+		} else {
+			planned = append(planned, trial)
+		}
+	}
+
+	fmt.Printf("Plan: %d trials, %s order", len(planned), out.Order)
+	if out.Seed != nil {
+		fmt.Printf(" (seed %d)", *out.Seed)
+	}
+	fmt.Printf(", %d run(s) each", out.Runs)
+	if out.Suite != "" {
+		fmt.Printf(", suite %s", out.Suite)
+	}
+	fmt.Printf("\n")
+
+	for i, trial := range planned {
+		fmt.Printf("\n[%d] %s\n", i+1, trialLabel(trial.Tech, trial.Test, trial.Sweep, trial.Run, out.Runs))
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "    type\t%s\n", trial.Type)
+		if len(trial.DefaultParams) > 0 {
+			fmt.Fprintf(w, "    defaults\t%s\n", formatParams(trial.DefaultParams))
+		}
+		if len(trial.Overrides) > 0 {
+			fmt.Fprintf(w, "    overrides\t%s\n", formatParams(trial.Overrides))
+		}
+		for _, dataset := range trial.Datasets {
+			status := "present"
+			if !dataset.Present {
+				status = "generated"
+			}
+			fmt.Fprintf(w, "    data\t%s (%s)\n", dataset.Path, status)
+		}
+		if trial.Workspace != "" {
+			fmt.Fprintf(w, "    workspace\t%s\n", trial.Workspace)
+		}
+		if len(trial.Ports) > 0 {
+			fmt.Fprintf(w, "    ports\t%s\n", strings.Trim(fmt.Sprint(trial.Ports), "[]"))
+		}
+		for _, command := range trial.Commands {
+			fmt.Fprintf(w, "    %s\t%s\n", command.Role, shellJoin(command.Args))
+			fmt.Fprintf(w, "      dir\t%s\n", command.Dir)
+			for _, env := range command.Env {
+				fmt.Fprintf(w, "      env\t%s\n", env)
+			}
+		}
+		if trial.Client != "" {
+			fmt.Fprintf(w, "    client\t%s\n", trial.Client)
+		}
+		for _, note := range trial.Notes {
+			fmt.Fprintf(w, "    note\t%s\n", note)
+		}
+		w.Flush()
+	}
+
+	if len(skipped) > 0 {
+		fmt.Printf("\nSkipped:\n")
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		seen := map[string]bool{}
+		for _, trial := range skipped {
+			line := fmt.Sprintf("  %s - %s\t%s\t%s\n", trial.Tech, trial.Test, trial.Skip, trial.SkipReason)
+			if !seen[line] {
+				seen[line] = true
+				fmt.Fprint(w, line)
+			}
+		}
+		w.Flush()
+	}
+	return nil
+}
+
+// trialLabel names a trial in progress output, with its sweep combination
+// and, for repeated runs, which run it is.
+func trialLabel(tech, test string, sweep map[string]string, run, runs int) string {
+	label := fmt.Sprintf("%s - %s", tech, test)
+	if len(sweep) > 0 {
+		label += " (" + formatParams(sweep) + ")"
+	}
+	if runs > 1 {
+		label += fmt.Sprintf(" run %d/%d", run, runs)
+	}
+	return label
+}
+
+// shellJoin joins args into a command line that can be pasted into a shell.
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n\"'\\$`*?[]{}()<>|&;#~") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		quoted[i] = arg
+	}
+	return strings.Join(quoted, " ")
+}
@@ -4,9 +4,14 @@ import (
 	"fmt"
 	"os"
 
+	"performance-benchmark-suite/orchestrator/config"
+	"performance-benchmark-suite/orchestrator/runner"
+
 	"github.com/spf13/cobra"
 )
 
+var configPaths []string
+
 var rootCmd = &cobra.Command{
 	Use:   "benchmark-cli",
 	Short: "A comprehensive benchmarking framework for comparing performance across different technologies",
@@ -16,6 +21,13 @@ A CLI tool for running performance benchmarks across different technologies (Go,
 and various test types (RPS, File I/O, JSON operations, concurrency).
 
 The tool orchestrates test execution, monitors system resources, and generates comprehensive reports.`,
+	// Errors from a command's own work are not usage mistakes, and main
+	// prints the error itself
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return setupLogging()
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -24,8 +36,33 @@ func Execute() error {
 }
 
 func init() {
+	rootCmd.PersistentFlags().StringArrayVarP(&configPaths, "config", "c", nil,
+		"Config file or directory; repeat to layer overlays over the base (default: $"+config.ConfigEnv+", then ./config)")
+
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "warn",
+		"Lowest level of log records shown on stderr: debug, info, warn or error; runs log everything to a file in the output directory")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "text", "Log record format: text or json")
+
 	// Add subcommands here
 	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(dataCmd)
+	rootCmd.AddCommand(listCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(planCmd)
+}
+
+// loadConfig loads the configuration selected with --config and validates
+// it, printing any diagnostics. Errors stop the command.
+func loadConfig() (*config.Config, error) {
+	cfg, err := config.LoadConfig(configPaths...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %v", err)
+	}
+	if errors, _ := printDiagnostics(cfg.Validate(runner.BenchmarkTypes())); errors > 0 {
+		return nil, fmt.Errorf("configuration has %d errors, see 'benchmark-cli config validate'", errors)
+	}
+	return cfg, nil
 }
 
 func exitWithError(err error) {
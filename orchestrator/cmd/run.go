@@ -1,8 +1,13 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"performance-benchmark-suite/orchestrator/config"
 	"performance-benchmark-suite/orchestrator/report"
@@ -17,6 +22,17 @@ var (
 	outputDir      string
 	rpsDuration    string
 	rpsConnections int
+	extraParams    []string
+	verifyMode     string
+	workdir        string
+	profileKinds   string
+	suiteName      string
+	extraSweeps    []string
+	runOrder       string
+	runSeed        int64
+	runCount       int
+	resumePath     string
+	dryRun         bool
 )
 
 var runCmd = &cobra.Command{
@@ -26,97 +42,247 @@ var runCmd = &cobra.Command{
 
 Examples:
   benchmark-cli run --tech=all --test=all
+  benchmark-cli run --suite=quick
+  benchmark-cli run --suite=nightly --tech=go,node
   benchmark-cli run --tech=go,bun --test=file_read,json_write
-  benchmark-cli run --tech=node --test=http_server --rps-duration=30s`,
+  benchmark-cli run --tech=node --test=http_server --rps-duration=30s
+  benchmark-cli run --tech=go --test=grpc_server --param mode=bidi_stream --param concurrency=100
+  benchmark-cli run --tech=go,node --test=file_write --sweep size=1024,65536,1048576
+  benchmark-cli run --tech=go,node --test=file_write,http_server --sweep http_server:connections=10,100,1000
+  benchmark-cli run --tech=go,node,bun --test=json_write --runs=5 --order=interleave
+  benchmark-cli run --tech=all --test=all --runs=3 --order=random --seed=42
+  benchmark-cli run --resume=reports/journal_2025-01-01T10-00-00Z.jsonl
+  benchmark-cli run --suite=nightly --dry-run
+  benchmark-cli run --tech=go,node,bun --test=json_write --verify=strict
+  benchmark-cli run --tech=go --test=file_write_lines --workdir=/dev/shm
+  benchmark-cli run --tech=go,node --test=json_read --profile
+  benchmark-cli run --tech=go --test=http_server --profile=cpu,trace`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		// Everything down to debug is logged to a file next to the report
+		var logPath string
+		if !dryRun {
+			logDir := outputDir
+			if resumePath != "" && !cmd.Flags().Changed("output-dir") {
+				logDir = filepath.Dir(resumePath)
+			}
+			path, closeLog, err := openLogFile(logDir)
+			if err != nil {
+				return err
+			}
+			defer closeLog()
+			logPath = path
+		}
+
 		// Load configuration
-		cfg, err := config.LoadConfig("")
+		cfg, err := loadConfig()
 		if err != nil {
-			return fmt.Errorf("failed to load configuration: %v", err)
+			return err
 		}
 
-		// Parse technologies
-		techList := parseList(technologies)
-		if len(techList) == 0 {
-			return fmt.Errorf("no technologies specified")
+		if verifyMode != "off" && verifyMode != "warn" && verifyMode != "strict" {
+			return fmt.Errorf("invalid verify mode: %s (use 'off', 'warn' or 'strict')", verifyMode)
 		}
 
-		// Parse tests
-		testList := parseList(tests)
-		if len(testList) == 0 {
-			return fmt.Errorf("no tests specified")
-		}
+		// A resumed run continues the plan recorded in its journal, skipping
+		// the trials that already completed
+		var (
+			plan      *runPlan
+			execution *report.Execution
+			journal   *report.Journal
+			results   []report.BenchmarkResult
+			attempts  []report.JournalTrial
+			completed = make(map[string]bool)
+			session   *report.JournalSession
+		)
+		if resumePath != "" {
+			for _, name := range []string{"tech", "test", "suite", "param", "sweep", "runs", "order", "seed", "rps-duration", "rps-connections"} {
+				if cmd.Flags().Changed(name) {
+					return fmt.Errorf("--%s cannot be used with --resume, the journal's plan is resumed as recorded", name)
+				}
+			}
 
-		// Validate technologies
-		validTechs := cfg.ListTechnologies()
-		for _, tech := range techList {
-			if tech == "all" {
-				techList = validTechs
-				break
+			// A dry run only reads the journal, so it is left as it was for the
+			// resume that follows
+			var entries []report.JournalEntry
+			if dryRun {
+				entries, err = report.ReadJournal(resumePath)
+			} else if journal, entries, err = report.OpenJournal(resumePath); err == nil {
+				defer journal.Close()
 			}
-			if !cfg.ValidateTechnology(tech) {
-				return fmt.Errorf("invalid technology: %s", tech)
+			if err != nil {
+				return err
 			}
-		}
 
-		// Validate tests
-		for _, test := range testList {
-			if test == "all" {
-				// Get all tests from all technologies
-				testList = []string{}
-				for _, tech := range validTechs {
-					if tests, err := cfg.ListBenchmarks(tech); err == nil {
-						testList = append(testList, tests...)
+			sessions := 0
+			for _, entry := range entries {
+				switch {
+				case entry.Session != nil:
+					if session == nil {
+						session = entry.Session
+					}
+					sessions++
+				case entry.Trial != nil:
+					if session == nil {
+						return fmt.Errorf("journal %s has trials before its first session", resumePath)
 					}
+					attempts = append(attempts, *entry.Trial)
+					if entry.Result != nil {
+						completed[entry.Trial.Key()] = true
+						results = append(results, *entry.Result)
+					}
+				}
+				if session != nil && execution == nil {
+					execution = &report.Execution{Order: session.Order, Seed: session.Seed, Runs: session.Runs}
 				}
-				break
 			}
-		}
-
-		// Remove duplicates
-		techList = removeDuplicates(techList)
-		testList = removeDuplicates(testList)
+			if session == nil {
+				return fmt.Errorf("journal %s records no session to resume", resumePath)
+			}
+			execution.Resumes = sessions
 
-		fmt.Printf("Running benchmarks for technologies: %v\n", techList)
-		fmt.Printf("Running tests: %v\n", testList)
+			plan = &runPlan{execution: execution}
+			for _, planned := range session.Plan {
+				plan.trials = append(plan.trials, runner.Trial{
+					Tech:   planned.Tech,
+					Test:   planned.Test,
+					Params: planned.Params,
+					Sweep:  planned.Sweep,
+					Run:    planned.Run,
+				})
+			}
+			suiteName, runCount = session.Suite, session.Runs
+			if !cmd.Flags().Changed("output-dir") {
+				outputDir = filepath.Dir(resumePath)
+			}
+			fmt.Printf("Resuming %s: %d of %d trials completed\n", resumePath, len(completed), len(plan.trials))
+		} else {
+			if plan, err = planTrials(cmd, cfg); err != nil {
+				return err
+			}
+			execution = plan.execution
+		}
 
 		// Create runner
-		benchmarkRunner, err := runner.NewRunner()
+		benchmarkRunner, err := newRunner(cfg)
 		if err != nil {
-			return fmt.Errorf("failed to create runner: %v", err)
+			return err
 		}
 
-		// Run benchmarks
-		var results []report.BenchmarkResult
-		for _, tech := range techList {
-			for _, test := range testList {
-				// Check if this technology supports this test
-				if !cfg.ValidateBenchmark(tech, test) {
-					fmt.Printf("Skipping %s - %s (not supported)\n", tech, test)
-					continue
+		if dryRun {
+			remaining := plan.trials[:0:0]
+			for _, trial := range plan.trials {
+				if !completed[journalTrial(trial).Key()] {
+					remaining = append(remaining, trial)
 				}
+			}
+			plan.trials = remaining
+			return printPlan(benchmarkRunner, plan, "table")
+		}
+
+		if suiteName != "" && plan.suite != nil {
+			fmt.Printf("Running suite %s: %s\n", suiteName, plan.suite.Description)
+		}
+		if plan.techs != nil {
+			fmt.Printf("Running benchmarks for technologies: %v\n", plan.techs)
+			fmt.Printf("Running tests: %v\n", plan.tests)
+		}
+		for _, skipped := range plan.unsupported {
+			fmt.Printf("Skipping %s - %s (not supported)\n", skipped.Tech, skipped.Test)
+		}
+		if execution.Seed != nil {
+			fmt.Printf("Random order with seed %d\n", *execution.Seed)
+		}
+		if profileKinds != "" {
+			fmt.Printf("Writing profiles to %s\n", benchmarkRunner.ProfileDir())
+		}
 
-				fmt.Printf("\nRunning %s - %s...\n", tech, test)
+		if journal == nil {
+			if journal, err = report.CreateJournal(outputDir); err != nil {
+				return err
+			}
+			defer journal.Close()
+			fmt.Printf("Journal: %s\n", journal.Path)
 
-				params := make(map[string]string)
-				if test == "http_server" {
-					params["duration"] = rpsDuration
-					params["connections"] = fmt.Sprintf("%d", rpsConnections)
-				}
+			session = &report.JournalSession{Order: execution.Order, Seed: execution.Seed, Runs: execution.Runs, Suite: suiteName}
+			for _, trial := range plan.trials {
+				session.Plan = append(session.Plan, journalTrial(trial))
+			}
+		}
+		fmt.Printf("Log: %s\n", logPath)
+		session.StartedAt = time.Now().UTC().Format(time.RFC3339)
+		if err := journal.Append(report.JournalEntry{Session: session}); err != nil {
+			return err
+		}
+
+		// An interrupt ends the trial in progress and stops the run once it
+		// is torn down, leaving the journal to resume from
+		stopInterrupt := benchmarkRunner.NotifyInterrupt()
+		defer stopInterrupt()
+
+		// Run benchmarks
+		for i, trial := range plan.trials {
+			label := trialLabel(trial.Tech, trial.Test, trial.Sweep, trial.Run, runCount)
+			if sig := benchmarkRunner.Interrupted(); sig != nil {
+				return interrupted(journal, &runner.InterruptedError{Signal: sig})
+			}
 
-				result, err := benchmarkRunner.RunBenchmark(tech, test, params)
+			entry := journalTrial(trial)
+			if completed[entry.Key()] {
+				fmt.Printf("Skipping %s (completed in journal)\n", label)
+				continue
+			}
+
+			// Trials that cannot run here are recorded as failed, so a resume
+			// retries them once the cause is fixed
+			var result *report.BenchmarkResult
+			if planned := benchmarkRunner.Plan(trial); planned.Skip != "" {
+				fmt.Printf("Skipping %s (%s: %s)\n", label, planned.Skip, planned.SkipReason)
+				entry.Error = fmt.Sprintf("skipped: %s: %s", planned.Skip, planned.SkipReason)
+			} else {
+				fmt.Printf("[%d/%d] Running %s...\n", i+1, len(plan.trials), label)
+				slog.Info("trial started", "tech", trial.Tech, "test", trial.Test, "run", trial.Run, "params", trial.Params)
+				started := time.Now()
+				result, err = benchmarkRunner.RunBenchmark(trial)
+				elapsed := time.Since(started).Round(10 * time.Millisecond)
+				var interruptedErr *runner.InterruptedError
+				if errors.As(err, &interruptedErr) {
+					slog.Info("trial interrupted", "tech", trial.Tech, "test", trial.Test, "run", trial.Run, "signal", interruptedErr.Signal.String())
+					return interrupted(journal, interruptedErr)
+				}
 				if err != nil {
-					fmt.Printf("Error running %s - %s: %v\n", tech, test, err)
-					continue
+					fmt.Printf("Error running %s: %v\n", label, err)
+					slog.Info("trial failed", "tech", trial.Tech, "test", trial.Test, "run", trial.Run, "elapsedMs", elapsed.Milliseconds(), "error", err)
+					entry.Error = err.Error()
+					result = nil
+				} else {
+					fmt.Printf("  done in %s\n", elapsed)
+					slog.Info("trial finished", "tech", trial.Tech, "test", trial.Test, "run", trial.Run, "elapsedMs", elapsed.Milliseconds())
+					result.Sweep = trial.Sweep
+					result.Run = trial.Run
+					results = append(results, *result)
 				}
+			}
+			attempts = append(attempts, entry)
 
-				results = append(results, *result)
+			// Record the trial before starting the next, so a crash loses nothing
+			if err := journal.Append(report.JournalEntry{Trial: &entry, Result: result}); err != nil {
+				return err
 			}
 		}
 
+		execution.Trials = report.LatestAttempts(attempts)
+
+		if verifyMode != "off" {
+			results = verifyResults(cfg, results, verifyMode == "strict")
+		}
+
 		// Generate report
 		if len(results) > 0 {
-			generator := report.NewGenerator()
+			generator := report.NewGenerator(cfg)
+			generator.SetSuite(suiteName)
+			generator.SetExecution(execution)
+			generator.SetJournal(journal.Path)
+			generator.SetLog(logPath)
 			reportPath, err := generator.GenerateReport(results, outputDir)
 			if err != nil {
 				return fmt.Errorf("failed to generate report: %v", err)
@@ -128,12 +294,58 @@ Examples:
 	},
 }
 
+// interrupted tells how to resume the run that err stopped.
+func interrupted(journal *report.Journal, err *runner.InterruptedError) error {
+	fmt.Fprintf(os.Stderr, "\nInterrupted, resume with --resume=%s\n", journal.Path)
+	return err
+}
+
+// journalTrial is the journal record of trial.
+func journalTrial(trial runner.Trial) report.JournalTrial {
+	return report.JournalTrial{
+		Trial:  report.Trial{Tech: trial.Tech, Test: trial.Test, Run: trial.Run, Sweep: trial.Sweep},
+		Params: trial.Params,
+	}
+}
+
 func init() {
-	runCmd.Flags().StringVarP(&technologies, "tech", "t", "all", "Comma-separated list of technologies to test (use 'all' for all available)")
-	runCmd.Flags().StringVarP(&tests, "test", "e", "all", "Comma-separated list of tests to run (use 'all' for all available)")
-	runCmd.Flags().StringVarP(&outputDir, "output-dir", "o", "./reports", "Directory to save the report")
-	runCmd.Flags().StringVar(&rpsDuration, "rps-duration", "15s", "Duration for RPS test")
-	runCmd.Flags().IntVar(&rpsConnections, "rps-connections", 100, "Number of concurrent connections for RPS test")
+	addSelectionFlags(runCmd)
+	runCmd.Flags().StringVar(&resumePath, "resume", "", "Resume an interrupted run from its journal, skipping completed trials")
+	runCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the resolved plan, as the plan command does, instead of running it")
+	runCmd.Flags().StringVar(&verifyMode, "verify", "warn", "Cross-technology work digest check: off, warn or strict (drop mismatched results)")
+}
+
+// verifyResults checks that technologies did the same work for each test and
+// reports mismatches. In strict mode mismatched results are left out; results
+// that are inconclusive because no digest has a majority are kept.
+func verifyResults(cfg *config.Config, results []report.BenchmarkResult, strict bool) []report.BenchmarkResult {
+	mismatches := runner.VerifyDigests(cfg, results)
+	if len(mismatches) == 0 {
+		return results
+	}
+
+	fmt.Printf("\nWork digest mismatches:\n")
+	for _, m := range mismatches {
+		if m.Expected == "" {
+			fmt.Printf("  %s - %s: digest %s, inconclusive (no majority)\n", m.Tech, m.Test, m.Digest)
+			continue
+		}
+		fmt.Printf("  %s - %s: digest %s, expected %s\n", m.Tech, m.Test, m.Digest, m.Expected)
+	}
+
+	if !strict {
+		return results
+	}
+
+	kept := results[:0]
+	for _, result := range results {
+		if result.Verification == runner.VerificationMismatch {
+			fmt.Printf("Dropping %s - %s from the report (digest mismatch)\n", result.Tech, result.Test)
+			continue
+		}
+		kept = append(kept, result)
+	}
+	return kept
 }
 
 func parseList(input string) []string {
@@ -143,6 +355,80 @@ func parseList(input string) []string {
 	return strings.Split(input, ",")
 }
 
+// parseParams turns repeated key=value flags into a parameter map.
+func parseParams(pairs []string) (map[string]string, error) {
+	params := make(map[string]string)
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid parameter %q (expected key=value)", pair)
+		}
+		params[key] = value
+	}
+	return params, nil
+}
+
+// sweep is a --sweep flag: the values to run a parameter with, for one test
+// or, when test is empty, for every selected test.
+type sweep struct {
+	test   string
+	key    string
+	values []string
+}
+
+// parseSweeps parses repeated [test:]key=v1,v2 flags.
+func parseSweeps(flags []string) ([]sweep, error) {
+	var sweeps []sweep
+	for _, flag := range flags {
+		pair, test := flag, ""
+		if before, after, ok := strings.Cut(flag, ":"); ok && !strings.Contains(before, "=") {
+			test, pair = before, after
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" || value == "" {
+			return nil, fmt.Errorf("invalid sweep %q (expected [test:]key=value1,value2,...)", flag)
+		}
+		sweeps = append(sweeps, sweep{test: test, key: key, values: removeDuplicates(parseList(value))})
+	}
+	return sweeps, nil
+}
+
+// resolveSweep layers the sweeps that apply to test: the benchmark's own,
+// then the suite's, then the command line's. Parameters set with --param pin
+// a key the configuration sweeps; --sweep replaces the parameter instead. A
+// --sweep without a test only applies when the benchmark has the key among
+// its default parameters or sweeps, so it never hands a test a flag its
+// script does not read.
+func resolveSweep(benchmark *config.Benchmark, suite *config.Suite, test string, overrides map[string]string, flags []sweep) map[string][]string {
+	resolved := make(map[string][]string)
+	for key, values := range benchmark.Sweep {
+		if _, pinned := suite.Params[test][key]; !pinned {
+			resolved[key] = values
+		}
+	}
+	for key, values := range suite.Sweeps[test] {
+		resolved[key] = values
+	}
+	for key := range overrides {
+		delete(resolved, key)
+	}
+	for _, flag := range flags {
+		if flag.test == test || flag.test == "" && usesParam(benchmark, suite.Sweeps[test], flag.key) {
+			resolved[flag.key] = flag.values
+		}
+	}
+	return resolved
+}
+
+// usesParam reports whether a benchmark reads key, judged by its default
+// parameters and the sweeps declared for it.
+func usesParam(benchmark *config.Benchmark, suiteSweep map[string][]string, key string) bool {
+	_, inDefaults := benchmark.DefaultParams[key]
+	_, inSweep := benchmark.Sweep[key]
+	_, inSuiteSweep := suiteSweep[key]
+	return inDefaults || inSweep || inSuiteSweep
+}
+
 func removeDuplicates(list []string) []string {
 	seen := make(map[string]bool)
 	result := []string{}
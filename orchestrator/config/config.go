@@ -2,65 +2,347 @@ package config
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 
 	"gopkg.in/yaml.v3"
 )
 
+// ConfigEnv names the config files or directories to load when none are
+// given on the command line, separated like PATH.
+const ConfigEnv = "BENCHMARK_CONFIG"
+
 type Config struct {
+	// Root is the project directory benchmark commands run in. Relative
+	// paths are resolved against the directory of the file that sets it.
+	Root         string                `yaml:"root,omitempty"`
 	Technologies map[string]Technology `yaml:"technologies"`
+	Suites       map[string]Suite      `yaml:"suites,omitempty"`
+
+	// Files lists every file that was loaded, in the order it was applied
+	Files []string `yaml:"-"`
+
+	// positions maps dotted key paths to where the last file setting them
+	// defines them, and schema holds the schema problems found while
+	// loading; Validate reports both
+	positions map[string]Position
+	schema    []Diagnostic
 }
 
 type Technology struct {
-	Name           string               `yaml:"name"`
-	VersionCommand []string             `yaml:"version_command"`
-	Benchmarks     map[string]Benchmark `yaml:"benchmarks"`
+	Name           string                  `yaml:"name"`
+	VersionCommand []string                `yaml:"version_command"`
+	Profile        map[string]ProfileFlags `yaml:"profile,omitempty"`
+	Benchmarks     map[string]Benchmark    `yaml:"benchmarks"`
+}
+
+// ProfileFlags enables one kind of profile for a technology's commands. Args
+// are inserted after the executable and Env is added to the environment;
+// {dir} in either is replaced with the directory the profile goes to.
+type ProfileFlags struct {
+	Args []string `yaml:"args,omitempty"`
+	Env  []string `yaml:"env,omitempty"`
 }
 
 type Benchmark struct {
 	Command       []string          `yaml:"command"`
 	Type          string            `yaml:"type"`
 	Port          int               `yaml:"port,omitempty"`
+	Server        string            `yaml:"server,omitempty"`
+	Requires      string            `yaml:"requires,omitempty"`
 	DefaultParams map[string]string `yaml:"default_params,omitempty"`
+
+	// Sweep lists values to run the benchmark with for each parameter; the
+	// runner executes every combination of them
+	Sweep map[string][]string `yaml:"sweep,omitempty"`
 }
 
-var globalConfig *Config
+// Suite is a named, reviewed selection of technologies and tests with
+// parameter overrides and sweeps per test. Empty lists select everything.
+type Suite struct {
+	Description  string                         `yaml:"description,omitempty"`
+	Technologies []string                       `yaml:"technologies,omitempty"`
+	Tests        []string                       `yaml:"tests,omitempty"`
+	Params       map[string]map[string]string   `yaml:"params,omitempty"`
+	Sweeps       map[string]map[string][]string `yaml:"sweeps,omitempty"`
+}
 
-func LoadConfig(configPath string) (*Config, error) {
-	if globalConfig != nil {
-		return globalConfig, nil
+// LoadConfig loads the given config files and directories and layers them
+// in order, so later ones override earlier ones. A directory contributes its
+// technologies.yaml, every file in its technologies.d directory and then its
+// suites.yaml.
+// Without paths, BENCHMARK_CONFIG is used, and otherwise the config directory
+// in the current directory or up to two levels above it.
+//
+// Unless a file sets root, the project root is the directory containing the
+// first path: the parent of a config directory, or of a config file's directory.
+func LoadConfig(paths ...string) (*Config, error) {
+	if len(paths) == 0 {
+		if env := os.Getenv(ConfigEnv); env != "" {
+			paths = filepath.SplitList(env)
+		}
+	}
+	if len(paths) == 0 {
+		dir, err := findConfigDir()
+		if err != nil {
+			return nil, err
+		}
+		paths = []string{dir}
+		slog.Debug("found config directory", "dir", dir)
 	}
 
-	// If no config path provided, look for default locations
-	if configPath == "" {
-		// Try relative to current directory
-		configPath = "config/technologies.yaml"
-		if _, err := os.Stat(configPath); os.IsNotExist(err) {
-			// Try relative to project root (two levels up from orchestrator)
-			configPath = filepath.Join("..", "..", "config", "technologies.yaml")
+	config := &Config{
+		Technologies: map[string]Technology{},
+		Suites:       map[string]Suite{},
+		positions:    map[string]Position{},
+	}
+	for i, path := range paths {
+		path, err := filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid config path %s: %v", path, err)
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config %s: %v", path, err)
+		}
+
+		files := []string{path}
+		if info.IsDir() {
+			if files, err = configDirFiles(path); err != nil {
+				return nil, err
+			}
+		}
+
+		if i == 0 {
+			config.Root = filepath.Dir(path)
+			if !info.IsDir() {
+				config.Root = filepath.Dir(config.Root)
+			}
+		}
+		for _, file := range files {
+			if err := config.loadFile(file); err != nil {
+				return nil, err
+			}
 		}
 	}
 
-	data, err := os.ReadFile(configPath)
+	return config, nil
+}
+
+// findConfigDir looks for the config directory from the current directory
+// upwards, so the CLI works from the project root and from orchestrator/.
+func findConfigDir() (string, error) {
+	for _, dir := range []string{"config", filepath.Join("..", "config"), filepath.Join("..", "..", "config")} {
+		if _, err := os.Stat(filepath.Join(dir, "technologies.yaml")); err == nil {
+			return dir, nil
+		}
+	}
+	return "", fmt.Errorf("no config/technologies.yaml found, use --config or %s to point at the configuration", ConfigEnv)
+}
+
+// configDirFiles lists the files a config directory contributes.
+func configDirFiles(dir string) ([]string, error) {
+	var files []string
+	base := filepath.Join(dir, "technologies.yaml")
+	if _, err := os.Stat(base); err == nil {
+		files = append(files, base)
+	}
+
+	parts, err := filepath.Glob(filepath.Join(dir, "technologies.d", "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(parts)
+	files = append(files, parts...)
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("config directory %s has no technologies.yaml or technologies.d/*.yaml", dir)
+	}
+
+	suites := filepath.Join(dir, "suites.yaml")
+	if _, err := os.Stat(suites); err == nil {
+		files = append(files, suites)
+	}
+	return files, nil
+}
+
+// loadFile parses one config file and layers it over c.
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read config file %s: %v", configPath, err)
+		return fmt.Errorf("failed to read config file %s: %v", path, err)
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %v", path, err)
+	}
+
+	// Check the file against the schema first so mistakes are reported with
+	// their line and column. Unknown keys still decode and values of the
+	// wrong kind are dropped, leaving them to Validate; anything else that
+	// cannot be decoded stops loading here.
+	var overlay Config
+	if len(document.Content) > 0 {
+		var diagnostics []Diagnostic
+		c.checkNode(displayPath(path), "", document.Content[0], reflect.TypeOf(overlay), &diagnostics)
+		if err := document.Decode(&overlay); err != nil {
+			if len(diagnostics) > 0 {
+				return &DiagnosticsError{Diagnostics: diagnostics}
+			}
+			return fmt.Errorf("failed to parse config file %s: %v", path, err)
+		}
+		c.schema = append(c.schema, diagnostics...)
+	}
+
+	if overlay.Root != "" {
+		c.Root = overlay.Root
+		if !filepath.IsAbs(c.Root) {
+			c.Root = filepath.Join(filepath.Dir(path), c.Root)
+		}
+	}
+	for key, tech := range overlay.Technologies {
+		if base, ok := c.Technologies[key]; ok {
+			slog.Debug("config overlays technology", "path", path, "tech", key)
+			tech = base.merge(tech)
+		}
+		c.Technologies[key] = tech
 	}
+	for name, suite := range overlay.Suites {
+		if base, ok := c.Suites[name]; ok {
+			slog.Debug("config overlays suite", "path", path, "suite", name)
+			suite = base.merge(suite)
+		}
+		c.Suites[name] = suite
+	}
+	c.Files = append(c.Files, path)
+	slog.Debug("loaded config file", "path", path, "technologies", len(overlay.Technologies), "suites", len(overlay.Suites))
+	return nil
+}
 
-	var config Config
-	if err := yaml.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %v", err)
+// merge layers overlay over t: fields the overlay sets replace t's, and
+// profiles, benchmarks, default parameters and sweeps are merged key by key.
+func (t Technology) merge(overlay Technology) Technology {
+	if overlay.Name != "" {
+		t.Name = overlay.Name
+	}
+	if len(overlay.VersionCommand) > 0 {
+		t.VersionCommand = overlay.VersionCommand
+	}
+
+	profile := make(map[string]ProfileFlags, len(t.Profile))
+	for kind, flags := range t.Profile {
+		profile[kind] = flags
+	}
+	for kind, flags := range overlay.Profile {
+		profile[kind] = flags
+	}
+	t.Profile = profile
+
+	benchmarks := make(map[string]Benchmark, len(t.Benchmarks))
+	for name, benchmark := range t.Benchmarks {
+		benchmarks[name] = benchmark
+	}
+	for name, benchmark := range overlay.Benchmarks {
+		if base, ok := benchmarks[name]; ok {
+			benchmark = base.merge(benchmark)
+		}
+		benchmarks[name] = benchmark
+	}
+	t.Benchmarks = benchmarks
+	return t
+}
+
+func (b Benchmark) merge(overlay Benchmark) Benchmark {
+	if len(overlay.Command) > 0 {
+		b.Command = overlay.Command
+	}
+	if overlay.Type != "" {
+		b.Type = overlay.Type
+	}
+	if overlay.Port != 0 {
+		b.Port = overlay.Port
+	}
+	if overlay.Server != "" {
+		b.Server = overlay.Server
+	}
+	if overlay.Requires != "" {
+		b.Requires = overlay.Requires
 	}
 
-	globalConfig = &config
-	return globalConfig, nil
+	params := make(map[string]string, len(b.DefaultParams))
+	for key, value := range b.DefaultParams {
+		params[key] = value
+	}
+	for key, value := range overlay.DefaultParams {
+		params[key] = value
+	}
+	b.DefaultParams = params
+
+	sweep := make(map[string][]string, len(b.Sweep))
+	for key, values := range b.Sweep {
+		sweep[key] = values
+	}
+	for key, values := range overlay.Sweep {
+		sweep[key] = values
+	}
+	b.Sweep = sweep
+	return b
+}
+
+func (s Suite) merge(overlay Suite) Suite {
+	if overlay.Description != "" {
+		s.Description = overlay.Description
+	}
+	if len(overlay.Technologies) > 0 {
+		s.Technologies = overlay.Technologies
+	}
+	if len(overlay.Tests) > 0 {
+		s.Tests = overlay.Tests
+	}
+
+	params := make(map[string]map[string]string, len(s.Params))
+	for test, values := range s.Params {
+		params[test] = values
+	}
+	for test, values := range overlay.Params {
+		merged := make(map[string]string, len(params[test])+len(values))
+		for key, value := range params[test] {
+			merged[key] = value
+		}
+		for key, value := range values {
+			merged[key] = value
+		}
+		params[test] = merged
+	}
+	s.Params = params
+
+	sweeps := make(map[string]map[string][]string, len(s.Sweeps))
+	for test, values := range s.Sweeps {
+		sweeps[test] = values
+	}
+	for test, values := range overlay.Sweeps {
+		merged := make(map[string][]string, len(sweeps[test])+len(values))
+		for key, list := range sweeps[test] {
+			merged[key] = list
+		}
+		for key, list := range values {
+			merged[key] = list
+		}
+		sweeps[test] = merged
+	}
+	s.Sweeps = sweeps
+	return s
 }
 
-func GetConfig() (*Config, error) {
-	if globalConfig == nil {
-		return LoadConfig("")
+func (c *Config) GetSuite(name string) (*Suite, error) {
+	if suite, exists := c.Suites[name]; exists {
+		return &suite, nil
 	}
-	return globalConfig, nil
+	return nil, fmt.Errorf("suite '%s' not found in configuration (available: %v)", name, sortedKeys(c.Suites))
 }
 
 func (c *Config) GetTechnology(tech string) (*Technology, error) {
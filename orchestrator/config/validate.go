@@ -0,0 +1,706 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BenchmarkType is a value a benchmark's type can take. Commandless types
+// are driven by the orchestrator itself and need no command.
+type BenchmarkType struct {
+	Name        string
+	Commandless bool
+}
+
+// ProfileKinds are the profiles a technology can declare flags for.
+var ProfileKinds = []string{"cpu", "heap", "trace"}
+
+// serverPort is where server benchmarks are load tested.
+const serverPort = 3000
+
+// Position is a location in a config file.
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// Diagnostic is a problem found in the configuration. Path is the dotted key
+// it concerns, e.g. technologies.go.benchmarks.file_read.command.
+type Diagnostic struct {
+	Position
+	Path    string
+	Message string
+	Warning bool
+}
+
+func (d Diagnostic) String() string {
+	severity := "error"
+	if d.Warning {
+		severity = "warning"
+	}
+	location := "<config>"
+	if d.File != "" {
+		location = fmt.Sprintf("%s:%d:%d", d.File, d.Line, d.Column)
+	}
+	if d.Path == "" {
+		return fmt.Sprintf("%s: %s: %s", location, severity, d.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s: %s", location, severity, d.Path, d.Message)
+}
+
+// DiagnosticsError is returned when a config file does not match the schema.
+type DiagnosticsError struct {
+	Diagnostics []Diagnostic
+}
+
+func (e *DiagnosticsError) Error() string {
+	lines := make([]string, len(e.Diagnostics))
+	for i, d := range e.Diagnostics {
+		lines[i] = d.String()
+	}
+	return "invalid configuration:\n" + strings.Join(lines, "\n")
+}
+
+// paramKind is the type of value a parameter must hold.
+type paramKind int
+
+const (
+	stringParam paramKind = iota
+	intParam
+	floatParam
+	durationParam
+	boolParam
+)
+
+// orchestratorParams are the parameters the runner itself reads for the
+// benchmark types it drives. Other parameters have no effect there.
+var orchestratorParams = map[string]map[string]paramKind{
+	"server": {
+		"duration":    durationParam,
+		"connections": intParam,
+	},
+	"websocket": {
+		"mode":         stringParam,
+		"connections":  intParam,
+		"rate":         floatParam,
+		"duration":     durationParam,
+		"message-size": intParam,
+	},
+	"grpc": {
+		"mode":            stringParam,
+		"concurrency":     intParam,
+		"connections":     intParam,
+		"duration":        durationParam,
+		"message-size":    intParam,
+		"stream-messages": intParam,
+	},
+	"concurrency_search": {
+		"start-clients":  intParam,
+		"max-clients":    intParam,
+		"resolution":     intParam,
+		"duration":       durationParam,
+		"warmup":         durationParam,
+		"p99-slo-ms":     floatParam,
+		"error-rate-slo": floatParam,
+		"path":           stringParam,
+	},
+	"cold_start": {
+		"iterations": intParam,
+		"warmup":     intParam,
+		"timeout":    durationParam,
+	},
+}
+
+// scriptParams are parameters the benchmark scripts share by convention.
+var scriptParams = map[string]paramKind{
+	"iterations": intParam,
+	"size":       intParam,
+	"lines":      intParam,
+	"workers":    intParam,
+	"operations": intParam,
+	"block-size": intParam,
+	"file-size":  intParam,
+	"workload":   intParam,
+	"keys":       intParam,
+	"fields":     intParam,
+	"n":          intParam,
+	"port":       intParam,
+	"timeout":    intParam,
+	"direct":     boolParam,
+}
+
+// checkNode compares node with the Go type it decodes into, recording the
+// position of every key under path and reporting unknown keys, duplicate
+// keys and values of the wrong kind.
+func (c *Config) checkNode(file, path string, node *yaml.Node, t reflect.Type, diagnostics *[]Diagnostic) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	// A value of the wrong kind is reported and then blanked, so the rest of
+	// the file still decodes and gets the semantic checks
+	report := func(format string, args ...interface{}) {
+		*diagnostics = append(*diagnostics, Diagnostic{
+			Position: Position{File: file, Line: node.Line, Column: node.Column},
+			Path:     path,
+			Message:  fmt.Sprintf(format, args...),
+		})
+		*node = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Line: node.Line, Column: node.Column}
+	}
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			report("expected a mapping")
+			return
+		}
+		seen := map[string]bool{}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			keyPath := joinPath(path, key.Value)
+			if seen[key.Value] {
+				*diagnostics = append(*diagnostics, Diagnostic{
+					Position: Position{File: file, Line: key.Line, Column: key.Column},
+					Path:     keyPath,
+					Message:  "duplicate key",
+				})
+				continue
+			}
+			seen[key.Value] = true
+
+			elem := t
+			if t.Kind() == reflect.Map {
+				elem = t.Elem()
+			} else if elem = fieldType(t, key.Value); elem == nil {
+				*diagnostics = append(*diagnostics, Diagnostic{
+					Position: Position{File: file, Line: key.Line, Column: key.Column},
+					Path:     keyPath,
+					Message:  fmt.Sprintf("unknown field %q", key.Value),
+				})
+				continue
+			}
+
+			c.positions[keyPath] = Position{File: file, Line: key.Line, Column: key.Column}
+			c.checkNode(file, keyPath, value, elem, diagnostics)
+		}
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			report("expected a list")
+			return
+		}
+		for i, item := range node.Content {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			c.positions[itemPath] = Position{File: file, Line: item.Line, Column: item.Column}
+			c.checkNode(file, itemPath, item, t.Elem(), diagnostics)
+		}
+	case reflect.String:
+		if node.Kind != yaml.ScalarNode {
+			report("expected a string")
+		}
+	case reflect.Int:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!int" {
+			report("expected an integer, got %q", node.Value)
+		}
+	}
+}
+
+// fieldType returns the type of the field of struct t with the given yaml
+// key, or nil if there is none.
+func fieldType(t reflect.Type, key string) reflect.Type {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == key && name != "-" {
+			return field.Type
+		}
+	}
+	return nil
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// displayPath shortens path to be relative to the working directory when it
+// lies below it.
+func displayPath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	if rel, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
+
+// Validate checks the loaded configuration for mistakes that would only show
+// up at run time: missing fields, types not among types, port conflicts,
+// parameters of the wrong type and commands that refer to missing files.
+// Diagnostics point at the file, line and column that defines the offending
+// value.
+func (c *Config) Validate(types []BenchmarkType) []Diagnostic {
+	v := &validator{config: c, types: types, diagnostics: append([]Diagnostic(nil), c.schema...)}
+
+	if info, err := os.Stat(c.Root); err != nil || !info.IsDir() {
+		v.errorf("root", "project root %s is not a directory", c.Root)
+	}
+	if len(c.Technologies) == 0 {
+		v.errorf("technologies", "no technologies are defined")
+	}
+
+	for _, key := range sortedKeys(c.Technologies) {
+		v.technology(key, c.Technologies[key])
+	}
+	for _, name := range sortedKeys(c.Suites) {
+		v.suite(name, c.Suites[name])
+	}
+
+	sort.SliceStable(v.diagnostics, func(i, j int) bool {
+		a, b := v.diagnostics[i], v.diagnostics[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return v.diagnostics
+}
+
+type validator struct {
+	config      *Config
+	types       []BenchmarkType
+	diagnostics []Diagnostic
+
+	// ports maps each port the current technology uses to the benchmark
+	// that claimed it first
+	ports map[int]string
+}
+
+// add records a diagnostic unless the schema check already reported a
+// problem with the value at path, which semantic checks would only repeat.
+func (v *validator) add(path string, warning bool, format string, args ...interface{}) {
+	for _, d := range v.config.schema {
+		if d.Path == path {
+			return
+		}
+	}
+	v.diagnostics = append(v.diagnostics, Diagnostic{
+		Position: v.config.position(path),
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+		Warning:  warning,
+	})
+}
+
+func (v *validator) errorf(path, format string, args ...interface{}) {
+	v.add(path, false, format, args...)
+}
+
+func (v *validator) warnf(path, format string, args ...interface{}) {
+	v.add(path, true, format, args...)
+}
+
+func (v *validator) technology(key string, tech Technology) {
+	path := "technologies." + key
+	if tech.Name == "" {
+		v.errorf(path+".name", "name is required")
+	}
+	if len(tech.VersionCommand) == 0 {
+		v.errorf(path+".version_command", "version_command is required")
+	}
+	if len(tech.Benchmarks) == 0 {
+		v.errorf(path, "no benchmarks are defined")
+	}
+
+	for _, kind := range sortedKeys(tech.Profile) {
+		kindPath := path + ".profile." + kind
+		if !contains(ProfileKinds, kind) {
+			v.errorf(kindPath, "unknown profile kind %q (use %s)", kind, strings.Join(ProfileKinds, ", "))
+		}
+		if flags := tech.Profile[kind]; len(flags.Args) == 0 && len(flags.Env) == 0 {
+			v.errorf(kindPath, "profile needs args or env")
+		}
+	}
+
+	v.ports = map[int]string{}
+	for _, name := range sortedKeys(tech.Benchmarks) {
+		v.benchmark(key, tech, name, tech.Benchmarks[name])
+	}
+}
+
+func (v *validator) benchmark(key string, tech Technology, name string, benchmark Benchmark) {
+	path := fmt.Sprintf("technologies.%s.benchmarks.%s", key, name)
+
+	benchmarkType, known := v.benchmarkType(benchmark.Type)
+	switch {
+	case benchmark.Type == "":
+		v.errorf(path+".type", "type is required (one of %s)", v.typeNames())
+	case !known:
+		v.errorf(path+".type", "unknown type %q (use %s)", benchmark.Type, v.typeNames())
+	}
+
+	if len(benchmark.Command) == 0 && !benchmarkType.Commandless {
+		v.errorf(path+".command", "command is required")
+	} else if len(benchmark.Command) > 0 && benchmark.Command[0] == "" {
+		v.errorf(path+".command", "command has an empty executable")
+	}
+	v.scripts(path, benchmark.Command)
+
+	if benchmark.Port < 0 || benchmark.Port > 65535 {
+		v.errorf(path+".port", "port %d is out of range", benchmark.Port)
+	}
+	if known {
+		v.portUse(name, path, benchmark)
+	}
+
+	v.references(key, tech, path, benchmark)
+	v.params(path, benchmark)
+	v.sweep(path+".sweep", benchmark.Type, benchmark.Sweep, map[string]bool{})
+}
+
+func (v *validator) benchmarkType(name string) (BenchmarkType, bool) {
+	for _, benchmarkType := range v.types {
+		if benchmarkType.Name == name {
+			return benchmarkType, true
+		}
+	}
+	return BenchmarkType{}, false
+}
+
+func (v *validator) typeNames() string {
+	names := make([]string, len(v.types))
+	for i, benchmarkType := range v.types {
+		names[i] = benchmarkType.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// scriptExtensions mark command arguments that name a script file.
+var scriptExtensions = []string{".go", ".js", ".mjs", ".cjs", ".ts", ".mts", ".py", ".sh"}
+
+// scripts checks that file arguments of a command exist below the root.
+// Arguments are taken as files when they contain a path separator or end in
+// a script extension.
+func (v *validator) scripts(path string, command []string) {
+	for i, arg := range command {
+		if i == 0 || strings.HasPrefix(arg, "-") {
+			continue
+		}
+		if !strings.Contains(arg, "/") && !contains(scriptExtensions, filepath.Ext(arg)) {
+			continue
+		}
+		file := arg
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(v.config.Root, file)
+		}
+		if _, err := os.Stat(file); err != nil {
+			v.errorf(fmt.Sprintf("%s.command[%d]", path, i), "%s does not exist", arg)
+		}
+	}
+}
+
+// references checks requires and server, which name another benchmark of
+// the same technology, and that ports agree with the servers they refer to.
+func (v *validator) references(key string, tech Technology, path string, benchmark Benchmark) {
+	if benchmark.Requires != "" {
+		required, ok := tech.Benchmarks[benchmark.Requires]
+		switch {
+		case !ok:
+			v.errorf(path+".requires", "%s has no benchmark %q", key, benchmark.Requires)
+		case required.Type != "server" && required.Type != "websocket":
+			v.errorf(path+".requires", "%s is a %s benchmark and cannot be used as a fixture", benchmark.Requires, required.Type)
+		default:
+			if port, ok := benchmark.DefaultParams["port"]; ok && port != strconv.Itoa(portOf(required)) {
+				v.warnf(path+".default_params.port", "port %s is replaced by the port of fixture %s (%d)", port, benchmark.Requires, portOf(required))
+			}
+		}
+	}
+
+	if benchmark.Type == "cold_start" {
+		server, ok := tech.Benchmarks[benchmark.Server]
+		switch {
+		case benchmark.Server == "":
+			v.errorf(path+".server", "cold_start benchmarks need server")
+		case !ok:
+			v.errorf(path+".server", "%s has no benchmark %q", key, benchmark.Server)
+		case server.Type != "server":
+			v.errorf(path+".server", "%s is a %s benchmark, cold starts need a server", benchmark.Server, server.Type)
+		case portOf(benchmark) != portOf(server):
+			v.errorf(path+".port", "port %d does not match %s, which listens on %d", portOf(benchmark), benchmark.Server, portOf(server))
+		}
+	} else if benchmark.Server != "" {
+		v.warnf(path+".server", "server is only used by cold_start benchmarks")
+	}
+}
+
+// portUse checks the port a benchmark listens on, or tells its script to
+// use, and reports ports another benchmark of the technology already uses.
+// Cold starts reuse their server's port and benchmarks with a fixture are
+// given the fixture's, so neither claims a port of its own.
+func (v *validator) portUse(name, path string, benchmark Benchmark) {
+	switch benchmark.Type {
+	case "server", "websocket", "grpc":
+		if benchmark.Type == "server" && benchmark.Port != 0 && benchmark.Port != serverPort {
+			v.errorf(path+".port", "server benchmarks are load tested on port %d, not %d", serverPort, benchmark.Port)
+		}
+		v.claimPort(portOf(benchmark), name, path+".port")
+	case "cold_start":
+	default:
+		if benchmark.Port != 0 {
+			v.warnf(path+".port", "port is not used by %s benchmarks", benchmark.Type)
+		}
+		if value, ok := benchmark.DefaultParams["port"]; ok && benchmark.Requires == "" {
+			if port, err := strconv.Atoi(value); err == nil {
+				v.claimPort(port, name, path+".default_params.port")
+			}
+		}
+	}
+}
+
+func (v *validator) claimPort(port int, name, path string) {
+	if port <= 0 {
+		return
+	}
+	if other, ok := v.ports[port]; ok {
+		v.warnf(path, "port %d is also used by %s", port, other)
+		return
+	}
+	v.ports[port] = name
+}
+
+// portOf is the port the runner uses for a benchmark that leaves it unset.
+func portOf(benchmark Benchmark) int {
+	switch {
+	case benchmark.Port != 0:
+		return benchmark.Port
+	case benchmark.Type == "grpc":
+		return 50051
+	default:
+		return serverPort
+	}
+}
+
+// suite checks that a suite selects technologies and tests that exist and
+// that its parameter overrides suit the benchmarks they apply to.
+func (v *validator) suite(name string, suite Suite) {
+	path := "suites." + name
+
+	techs := suite.Technologies
+	for i, tech := range techs {
+		if _, ok := v.config.Technologies[tech]; !ok {
+			v.errorf(fmt.Sprintf("%s.technologies[%d]", path, i), "unknown technology %q", tech)
+		}
+	}
+	if len(techs) == 0 {
+		techs = sortedKeys(v.config.Technologies)
+	}
+
+	supported := func(test string) bool {
+		for _, tech := range techs {
+			if _, ok := v.config.Technologies[tech].Benchmarks[test]; ok {
+				return true
+			}
+		}
+		return false
+	}
+	for i, test := range suite.Tests {
+		if !supported(test) {
+			v.errorf(fmt.Sprintf("%s.tests[%d]", path, i), "no selected technology has a %q benchmark", test)
+		}
+	}
+
+	for _, test := range sortedKeys(suite.Params) {
+		testPath := path + ".params." + test
+		if !supported(test) {
+			v.errorf(testPath, "no selected technology has a %q benchmark", test)
+			continue
+		}
+		if len(suite.Tests) > 0 && !contains(suite.Tests, test) {
+			v.warnf(testPath, "%s is not one of the suite's tests", test)
+		}
+
+		// Report each problem once, for the first technology it shows up in
+		reported := map[string]bool{}
+		for _, tech := range techs {
+			benchmark, ok := v.config.Technologies[tech].Benchmarks[test]
+			if !ok {
+				continue
+			}
+			for _, d := range checkParams(benchmark.Type, suite.Params[test]) {
+				if !reported[d.name] {
+					reported[d.name] = true
+					v.add(testPath+"."+d.name, d.warning, "%s", d.message)
+				}
+			}
+		}
+	}
+
+	for _, test := range sortedKeys(suite.Sweeps) {
+		testPath := path + ".sweeps." + test
+		if !supported(test) {
+			v.errorf(testPath, "no selected technology has a %q benchmark", test)
+			continue
+		}
+		if len(suite.Tests) > 0 && !contains(suite.Tests, test) {
+			v.warnf(testPath, "%s is not one of the suite's tests", test)
+		}
+
+		reported := map[string]bool{}
+		for _, tech := range techs {
+			if benchmark, ok := v.config.Technologies[tech].Benchmarks[test]; ok {
+				v.sweep(testPath, benchmark.Type, suite.Sweeps[test], reported)
+			}
+		}
+	}
+}
+
+// params checks default parameters against the types they are read as.
+func (v *validator) params(path string, benchmark Benchmark) {
+	for _, d := range checkParams(benchmark.Type, benchmark.DefaultParams) {
+		v.add(path+".default_params."+d.name, d.warning, "%s", d.message)
+	}
+}
+
+// sweep checks every value a sweep runs a parameter with. Problems already
+// in reported, keyed by path, are not reported again.
+func (v *validator) sweep(path, benchmarkType string, sweep map[string][]string, reported map[string]bool) {
+	report := func(path string, warning bool, message string) {
+		if !reported[path] {
+			reported[path] = true
+			v.add(path, warning, "%s", message)
+		}
+	}
+	for _, name := range sortedKeys(sweep) {
+		values := sweep[name]
+		if len(values) == 0 {
+			report(path+"."+name, false, "sweep needs at least one value")
+			continue
+		}
+		seen := map[string]bool{}
+		for i, value := range values {
+			valuePath := fmt.Sprintf("%s.%s[%d]", path, name, i)
+			if seen[value] {
+				report(valuePath, true, fmt.Sprintf("%s is swept over %q twice", name, value))
+			}
+			seen[value] = true
+			for _, d := range checkParams(benchmarkType, map[string]string{name: value}) {
+				if d.warning {
+					// Unused parameters are reported once for the whole sweep
+					report(path+"."+name, true, d.message)
+					continue
+				}
+				report(valuePath, false, d.message)
+			}
+		}
+	}
+}
+
+type paramProblem struct {
+	name    string
+	message string
+	warning bool
+}
+
+// checkParams checks params for a benchmark of the given type: parameters
+// the orchestrator reads must parse, unused ones get a warning, and the
+// conventional script parameters must have the right type.
+func checkParams(benchmarkType string, params map[string]string) []paramProblem {
+	var problems []paramProblem
+	known, driven := orchestratorParams[benchmarkType]
+	for _, name := range sortedKeys(params) {
+		kind, ok := known[name]
+		if driven && !ok {
+			problems = append(problems, paramProblem{name, fmt.Sprintf("parameter is not used by %s benchmarks", benchmarkType), true})
+			continue
+		}
+		if !driven {
+			if kind, ok = scriptParams[name]; !ok {
+				continue
+			}
+		}
+		if err := checkParam(kind, params[name]); err != nil {
+			problems = append(problems, paramProblem{name, err.Error(), false})
+		}
+	}
+	return problems
+}
+
+func checkParam(kind paramKind, value string) error {
+	var err error
+	switch kind {
+	case intParam:
+		_, err = strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("expected an integer, got %q", value)
+		}
+	case floatParam:
+		_, err = strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("expected a number, got %q", value)
+		}
+	case durationParam:
+		if _, err = strconv.ParseFloat(value, 64); err != nil {
+			if _, err = time.ParseDuration(value); err != nil {
+				return fmt.Errorf("expected a duration such as 10s, got %q", value)
+			}
+		}
+	case boolParam:
+		if _, err = strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("expected true or false, got %q", value)
+		}
+	}
+	return nil
+}
+
+// position finds where path, or the closest key above it, is defined.
+func (c *Config) position(path string) Position {
+	for path != "" {
+		if pos, ok := c.positions[path]; ok {
+			return pos
+		}
+		if i := strings.LastIndexAny(path, ".["); i >= 0 {
+			path = path[:i]
+		} else {
+			path = ""
+		}
+	}
+	return Position{}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,237 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var testTypes = []BenchmarkType{
+	{Name: "benchmark"},
+	{Name: "cold_start", Commandless: true},
+	{Name: "server"},
+}
+
+// writeTestConfig writes technologies.yaml into a config directory of a
+// fresh project root, together with the given files relative to that root,
+// and returns the config directory.
+func writeTestConfig(t *testing.T, technologies string, files ...string) string {
+	t.Helper()
+	root := t.TempDir()
+	dir := filepath.Join(root, "config")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "technologies.yaml"), []byte(technologies), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		path := filepath.Join(root, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func loadTestConfig(t *testing.T, technologies string, files ...string) *Config {
+	t.Helper()
+	config, err := LoadConfig(writeTestConfig(t, technologies, files...))
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	return config
+}
+
+type diagnostic struct {
+	Line    int
+	Column  int
+	Path    string
+	Message string
+	Warning bool
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		technologies string
+		files        []string
+		want         []diagnostic
+	}{
+		{
+			name: "valid",
+			technologies: `technologies:
+  go:
+    name: "Go"
+    version_command: ["go", "version"]
+    benchmarks:
+      file_read:
+        command: ["go", "run", "benchmarks/go/file_read/main.go"]
+        type: "benchmark"
+`,
+			files: []string{"benchmarks/go/file_read/main.go"},
+		},
+		{
+			name: "wrong kinds",
+			technologies: `technologies:
+  go:
+    name: "Go"
+    version_command: ["go", "version"]
+    benchmarks:
+      http_server:
+        command: "go run main.go"
+        type: "server"
+        port: "abc"
+`,
+			want: []diagnostic{
+				{Line: 7, Column: 18, Path: "technologies.go.benchmarks.http_server.command", Message: "expected a list"},
+				{Line: 9, Column: 15, Path: "technologies.go.benchmarks.http_server.port", Message: `expected an integer, got "abc"`},
+			},
+		},
+		{
+			name: "unknown key",
+			technologies: `technologies:
+  go:
+    name: "Go"
+    version_command: ["go", "version"]
+    benchmarks:
+      file_read:
+        command: ["go", "version"]
+        type: "benchmark"
+        timeout: 10
+`,
+			want: []diagnostic{
+				{Line: 9, Column: 9, Path: "technologies.go.benchmarks.file_read.timeout", Message: `unknown field "timeout"`},
+			},
+		},
+		{
+			name: "semantic problems point at their keys",
+			technologies: `technologies:
+  go:
+    name: "Go"
+    version_command: ["go", "version"]
+    benchmarks:
+      file_read:
+        command: ["go", "run", "benchmarks/go/missing.go"]
+        type: "benchmark"
+      cold:
+        type: "cold-start"
+`,
+			want: []diagnostic{
+				{Line: 7, Column: 32, Path: "technologies.go.benchmarks.file_read.command[2]", Message: "benchmarks/go/missing.go does not exist"},
+				{Line: 9, Column: 7, Path: "technologies.go.benchmarks.cold.command", Message: "command is required"},
+				{Line: 10, Column: 9, Path: "technologies.go.benchmarks.cold.type", Message: `unknown type "cold-start" (use benchmark, cold_start, server)`},
+			},
+		},
+		{
+			name: "schema and semantic problems together",
+			technologies: `technologies:
+  go:
+    version_command: ["go", "version"]
+    benchmarks:
+      file_read:
+        command: ["go", "version"]
+        type: "benchmark"
+        port: [3000]
+`,
+			want: []diagnostic{
+				{Line: 2, Column: 3, Path: "technologies.go.name", Message: "name is required"},
+				{Line: 8, Column: 15, Path: "technologies.go.benchmarks.file_read.port", Message: `expected an integer, got ""`},
+			},
+		},
+		{
+			name: "ports used twice",
+			technologies: `technologies:
+  go:
+    name: "Go"
+    version_command: ["go", "version"]
+    benchmarks:
+      http_server:
+        command: ["go", "version"]
+        type: "server"
+        port: 3000
+      server_cold_start:
+        type: "cold_start"
+        server: "http_server"
+        port: 3000
+      client:
+        command: ["go", "version"]
+        type: "benchmark"
+        requires: "http_server"
+        default_params:
+          port: "3000"
+      load:
+        command: ["go", "version"]
+        type: "benchmark"
+        default_params:
+          port: "3000"
+`,
+			want: []diagnostic{
+				{Line: 24, Column: 11, Path: "technologies.go.benchmarks.load.default_params.port", Message: "port 3000 is also used by http_server", Warning: true},
+			},
+		},
+		{
+			name: "unknown types skip port checks",
+			technologies: `technologies:
+  go:
+    name: "Go"
+    version_command: ["go", "version"]
+    benchmarks:
+      http_server:
+        command: ["go", "version"]
+        type: "server"
+      ws:
+        command: ["go", "version"]
+        type: "websockets"
+        port: 3000
+`,
+			want: []diagnostic{
+				{Line: 11, Column: 9, Path: "technologies.go.benchmarks.ws.type", Message: `unknown type "websockets" (use benchmark, cold_start, server)`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := loadTestConfig(t, tt.technologies, tt.files...)
+
+			var got []diagnostic
+			for _, d := range config.Validate(testTypes) {
+				if d.File != "" && filepath.Base(d.File) != "technologies.yaml" {
+					t.Errorf("diagnostic %s points at %s", d, d.File)
+				}
+				got = append(got, diagnostic{d.Line, d.Column, d.Path, d.Message, d.Warning})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigDuplicateKey(t *testing.T) {
+	dir := writeTestConfig(t, `technologies:
+  go:
+    name: "Go"
+    name: "Golang"
+    version_command: ["go", "version"]
+`)
+
+	_, err := LoadConfig(dir)
+	diagnostics, ok := err.(*DiagnosticsError)
+	if !ok {
+		t.Fatalf("got error %v, want a *DiagnosticsError", err)
+	}
+	want := []diagnostic{{Line: 4, Column: 5, Path: "technologies.go.name", Message: "duplicate key"}}
+	var got []diagnostic
+	for _, d := range diagnostics.Diagnostics {
+		got = append(got, diagnostic{d.Line, d.Column, d.Path, d.Message, d.Warning})
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %+v\nwant %+v", got, want)
+	}
+}
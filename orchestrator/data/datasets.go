@@ -0,0 +1,472 @@
+package data
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// Dataset is a named test data file. Its content depends only on the seed, so
+// the same seed always produces byte-identical files.
+type Dataset struct {
+	Name        string
+	Description string
+	generate    func(w *bufio.Writer, rng *rand.Rand) error
+}
+
+const (
+	sizeSmall  = 1024
+	sizeMedium = 1024 * 1024
+	sizeLarge  = 10 * 1024 * 1024
+	linesLarge = 1000000
+)
+
+// Datasets lists every dataset the benchmarks read, in generation order.
+var Datasets = []Dataset{
+	{Name: "small.txt", Description: "1KB text", generate: textContent(sizeSmall)},
+	{Name: "medium.txt", Description: "1MB text", generate: textContent(sizeMedium)},
+	{Name: "large.txt", Description: "10MB text", generate: textContent(sizeLarge)},
+	{Name: "xlarge_lines.txt", Description: "1M text lines", generate: textLines(linesLarge)},
+	{Name: "small.json", Description: "1KB JSON document", generate: jsonContent(sizeSmall)},
+	{Name: "medium.json", Description: "1MB JSON document", generate: jsonContent(sizeMedium)},
+	{Name: "large.json", Description: "10MB JSON document", generate: jsonContent(sizeLarge)},
+	{Name: "xlarge_lines.json", Description: "1M JSON lines", generate: jsonLines(linesLarge)},
+	{Name: "shape_flat.json", Description: "10MB flat records", generate: shapeDocument("flat", sizeLarge)},
+	{Name: "shape_flat_lines.json", Description: "10MB flat records, one per line", generate: shapeLines("flat", sizeLarge)},
+	{Name: "shape_nested.json", Description: "10MB nested records", generate: shapeDocument("nested", sizeLarge)},
+	{Name: "shape_nested_lines.json", Description: "10MB nested records, one per line", generate: shapeLines("nested", sizeLarge)},
+	{Name: "shape_numeric.json", Description: "10MB numeric records", generate: shapeDocument("numeric", sizeLarge)},
+	{Name: "shape_numeric_lines.json", Description: "10MB numeric records, one per line", generate: shapeLines("numeric", sizeLarge)},
+	{Name: "shape_string.json", Description: "10MB string records", generate: shapeDocument("string", sizeLarge)},
+	{Name: "shape_string_lines.json", Description: "10MB string records, one per line", generate: shapeLines("string", sizeLarge)},
+}
+
+// Lookup returns the dataset with the given file name.
+func Lookup(name string) (*Dataset, bool) {
+	for i := range Datasets {
+		if Datasets[i].Name == name {
+			return &Datasets[i], true
+		}
+	}
+	return nil, false
+}
+
+// Names returns the names of all datasets.
+func Names() []string {
+	names := make([]string, len(Datasets))
+	for i, dataset := range Datasets {
+		names[i] = dataset.Name
+	}
+	return names
+}
+
+const loremIpsum = "Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat. Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur. Excepteur sint occaecat cupidatat non proident, sunt in culpa qui officia deserunt mollit anim id est laborum. Sed ut perspiciatis unde omnis iste natus error sit voluptatem accusantium doloremque laudantium. Totam rem aperiam, eaque ipsa quae ab illo inventore veritatis et quasi architecto beatae vitae dicta sunt explicabo. Nemo enim ipsam voluptatem quia voluptas sit aspernatur aut odit aut fugit, sed quia consequuntur magni dolores eos qui ratione voluptatem sequi nesciunt. Neque porro quisquam est, qui dolorem ipsum quia dolor sit amet, consectetur, adipisci velit. Sed quia non numquam eius modi tempora incidunt ut labore et dolore magnam aliquam quaerat voluptatem. Ut enim ad minima veniam, quis nostrum exercitationem ullam corporis suscipit laboriosam, nisi ut aliquid ex ea commodi consequatur. Quis autem vel eum iure reprehenderit qui in ea voluptate velit esse quam nihil molestiae consequatur. Vel illum qui dolorem eum fugiat quo voluptas nulla pariatur. "
+
+var sampleTexts = []string{
+	"Lorem ipsum dolor sit amet, consectetur adipiscing elit.",
+	"Sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.",
+	"Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris.",
+	"Duis aute irure dolor in reprehenderit in voluptate velit esse cillum.",
+	"Excepteur sint occaecat cupidatat non proident, sunt in culpa.",
+	"Nemo enim ipsam voluptatem quia voluptas sit aspernatur aut odit.",
+	"Sed quia non numquam eius modi tempora incidunt ut labore.",
+	"Quis autem vel eum iure reprehenderit qui in ea voluptate velit.",
+	"At vero eos et accusamus et iusto odio dignissimos ducimus.",
+	"Et harum quidem rerum facilis est et expedita distinctio.",
+}
+
+// baseTime anchors every generated timestamp so output never depends on the clock
+var baseTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func timestamp(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+func textContent(size int) func(*bufio.Writer, *rand.Rand) error {
+	return func(w *bufio.Writer, rng *rand.Rand) error {
+		for written := 0; written < size; {
+			chunk := loremIpsum
+			if remaining := size - written; len(chunk) > remaining {
+				chunk = chunk[:remaining]
+			}
+			n, err := w.WriteString(chunk)
+			if err != nil {
+				return err
+			}
+			written += n
+		}
+		return nil
+	}
+}
+
+func textLines(lines int) func(*bufio.Writer, *rand.Rand) error {
+	return func(w *bufio.Writer, rng *rand.Rand) error {
+		for i := 0; i < lines; i++ {
+			if _, err := fmt.Fprintf(w, "Line %07d: %s\n", i+1, sampleTexts[i%len(sampleTexts)]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+type jsonItem struct {
+	ID          int          `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Tags        []string     `json:"tags"`
+	Metadata    jsonItemMeta `json:"metadata"`
+	Data        string       `json:"data"`
+}
+
+type jsonItemMeta struct {
+	Created  string `json:"created"`
+	Version  string `json:"version"`
+	Category string `json:"category"`
+	Priority string `json:"priority"`
+}
+
+// jsonContent writes a pretty-printed array of items that stops once the
+// document reaches size bytes.
+func jsonContent(size int) func(*bufio.Writer, *rand.Rand) error {
+	return func(w *bufio.Writer, rng *rand.Rand) error {
+		w.WriteString("[")
+		written := 1
+		for i := 0; written < size; i++ {
+			offset := rng.Intn(len(loremIpsum) - 100)
+			item := jsonItem{
+				ID:          i,
+				Name:        fmt.Sprintf("Item_%d", i+1),
+				Description: "This is a sample item for benchmarking JSON operations",
+				Tags:        []string{"benchmark", "test", "data"},
+				Metadata: jsonItemMeta{
+					Created:  timestamp(baseTime.Add(time.Duration(i) * time.Second)),
+					Version:  "1.0.0",
+					Category: "test",
+					Priority: "medium",
+				},
+				Data: loremIpsum[offset : offset+100],
+			}
+			itemJSON, err := json.MarshalIndent(item, "  ", "  ")
+			if err != nil {
+				return err
+			}
+			separator := "\n  "
+			if i > 0 {
+				separator = ",\n  "
+			}
+			w.WriteString(separator)
+			w.Write(itemJSON)
+			written += len(separator) + len(itemJSON)
+		}
+		_, err := w.WriteString("\n]")
+		return err
+	}
+}
+
+type lineItem struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Category    string   `json:"category"`
+	Priority    string   `json:"priority"`
+	Tags        []string `json:"tags"`
+	Timestamp   string   `json:"timestamp"`
+	Value       int      `json:"value"`
+	Active      bool     `json:"active"`
+}
+
+func jsonLines(lines int) func(*bufio.Writer, *rand.Rand) error {
+	categories := []string{"test", "benchmark", "data", "sample", "mock"}
+	priorities := []string{"low", "medium", "high", "critical"}
+	tags := [][]string{
+		{"benchmark", "test"},
+		{"data", "sample"},
+		{"mock", "test", "data"},
+		{"performance", "benchmark"},
+		{"json", "test"},
+	}
+
+	return func(w *bufio.Writer, rng *rand.Rand) error {
+		for i := 0; i < lines; i++ {
+			item := lineItem{
+				ID:          i + 1,
+				Name:        fmt.Sprintf("Item_%07d", i+1),
+				Description: fmt.Sprintf("Sample item %d for benchmarking JSON line operations", i+1),
+				Category:    categories[i%len(categories)],
+				Priority:    priorities[i%len(priorities)],
+				Tags:        tags[i%len(tags)],
+				Timestamp:   timestamp(baseTime.Add(time.Duration(i) * time.Second)),
+				Value:       rng.Intn(10000),
+				Active:      i%3 == 0,
+			}
+			if err := writeJSONLine(w, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func writeJSONLine(w *bufio.Writer, value interface{}) error {
+	line, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	w.Write(line)
+	return w.WriteByte('\n')
+}
+
+// shapeDocument writes a compact array of shape records of at least size bytes.
+func shapeDocument(shape string, size int) func(*bufio.Writer, *rand.Rand) error {
+	return func(w *bufio.Writer, rng *rand.Rand) error {
+		w.WriteString("[")
+		written := 2
+		for i := 0; written < size; i++ {
+			record, err := json.Marshal(shapeRecord(shape, i, rng))
+			if err != nil {
+				return err
+			}
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			w.Write(record)
+			written += len(record) + 1
+		}
+		_, err := w.WriteString("]")
+		return err
+	}
+}
+
+// shapeLines writes one shape record per line until size bytes are reached.
+func shapeLines(shape string, size int) func(*bufio.Writer, *rand.Rand) error {
+	return func(w *bufio.Writer, rng *rand.Rand) error {
+		written := 0
+		for i := 0; written < size; i++ {
+			record, err := json.Marshal(shapeRecord(shape, i, rng))
+			if err != nil {
+				return err
+			}
+			w.Write(record)
+			w.WriteByte('\n')
+			written += len(record) + 1
+		}
+		return nil
+	}
+}
+
+var (
+	countries = []string{"US", "DE", "IN", "BR", "JP", "FR", "GB", "CA"}
+	colors    = []string{"red", "green", "blue", "black", "white"}
+	sizes     = []string{"XS", "S", "M", "L", "XL"}
+	words     = []string{"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "eiusmod", "tempor", "incididunt"}
+)
+
+func randomWords(rng *rand.Rand, count int) string {
+	out := make([]string, count)
+	for i := range out {
+		out[i] = words[rng.Intn(len(words))]
+	}
+	return strings.Join(out, " ")
+}
+
+// round2 keeps generated decimals short, like prices and scores usually are
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+type flatRecord struct {
+	ID        int     `json:"id"`
+	Name      string  `json:"name"`
+	Email     string  `json:"email"`
+	Active    bool    `json:"active"`
+	Score     float64 `json:"score"`
+	Age       int     `json:"age"`
+	Country   string  `json:"country"`
+	Category  string  `json:"category"`
+	CreatedAt string  `json:"created_at"`
+}
+
+type nestedRecord struct {
+	ID    int         `json:"id"`
+	User  nestedUser  `json:"user"`
+	Order nestedOrder `json:"order"`
+	Meta  nestedMeta  `json:"meta"`
+}
+
+type nestedUser struct {
+	ID      int           `json:"id"`
+	Name    string        `json:"name"`
+	Address nestedAddress `json:"address"`
+}
+
+type nestedAddress struct {
+	Street string    `json:"street"`
+	City   string    `json:"city"`
+	Zip    string    `json:"zip"`
+	Geo    nestedGeo `json:"geo"`
+}
+
+type nestedGeo struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+type nestedOrder struct {
+	Items  []nestedItem `json:"items"`
+	Totals nestedTotals `json:"totals"`
+}
+
+type nestedItem struct {
+	SKU        string           `json:"sku"`
+	Quantity   int              `json:"quantity"`
+	Price      float64          `json:"price"`
+	Attributes nestedAttributes `json:"attributes"`
+}
+
+type nestedAttributes struct {
+	Color string `json:"color"`
+	Size  string `json:"size"`
+}
+
+type nestedTotals struct {
+	Subtotal float64 `json:"subtotal"`
+	Tax      float64 `json:"tax"`
+	Total    float64 `json:"total"`
+}
+
+type nestedMeta struct {
+	Tags    []string        `json:"tags"`
+	History []nestedHistory `json:"history"`
+}
+
+type nestedHistory struct {
+	Timestamp string `json:"timestamp"`
+	Event     string `json:"event"`
+}
+
+type numericRecord struct {
+	ID     int          `json:"id"`
+	Values []float64    `json:"values"`
+	Counts []int        `json:"counts"`
+	Stats  numericStats `json:"stats"`
+}
+
+type numericStats struct {
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Mean   float64 `json:"mean"`
+	Stddev float64 `json:"stddev"`
+}
+
+type stringRecord struct {
+	ID     int      `json:"id"`
+	Title  string   `json:"title"`
+	Body   string   `json:"body"`
+	Author string   `json:"author"`
+	URL    string   `json:"url"`
+	Tags   []string `json:"tags"`
+}
+
+// shapeRecord builds record i of a payload shape. Field layout matches the
+// record types in benchmarks/go/json_read and json_read_lines.
+func shapeRecord(shape string, i int, rng *rand.Rand) interface{} {
+	switch shape {
+	case "flat":
+		return flatRecord{
+			ID:        i,
+			Name:      fmt.Sprintf("User %d", i),
+			Email:     fmt.Sprintf("user%d@example.com", i),
+			Active:    i%3 != 0,
+			Score:     round2(rng.Float64() * 100),
+			Age:       18 + rng.Intn(60),
+			Country:   countries[rng.Intn(len(countries))],
+			Category:  fmt.Sprintf("category_%d", i%20),
+			CreatedAt: timestamp(baseTime.Add(time.Duration(i) * time.Minute)),
+		}
+	case "nested":
+		items := make([]nestedItem, 3)
+		var subtotal float64
+		for n := range items {
+			items[n] = nestedItem{
+				SKU:      fmt.Sprintf("SKU-%d-%d", i, n),
+				Quantity: 1 + rng.Intn(5),
+				Price:    round2(rng.Float64() * 100),
+				Attributes: nestedAttributes{
+					Color: colors[rng.Intn(len(colors))],
+					Size:  sizes[rng.Intn(len(sizes))],
+				},
+			}
+			subtotal += float64(items[n].Quantity) * items[n].Price
+		}
+		subtotal = round2(subtotal)
+		tax := round2(subtotal * 0.1)
+		return nestedRecord{
+			ID: i,
+			User: nestedUser{
+				ID:   i * 10,
+				Name: fmt.Sprintf("User %d", i),
+				Address: nestedAddress{
+					Street: fmt.Sprintf("%d Main Street", rng.Intn(999)),
+					City:   fmt.Sprintf("City %d", rng.Intn(50)),
+					Zip:    fmt.Sprintf("%05d", 10000+rng.Intn(89999)),
+					Geo: nestedGeo{
+						Lat: round2(rng.Float64()*180 - 90),
+						Lng: round2(rng.Float64()*360 - 180),
+					},
+				},
+			},
+			Order: nestedOrder{
+				Items:  items,
+				Totals: nestedTotals{Subtotal: subtotal, Tax: tax, Total: round2(subtotal + tax)},
+			},
+			Meta: nestedMeta{
+				Tags: []string{fmt.Sprintf("tag%d", i%7), fmt.Sprintf("tag%d", i%11)},
+				History: []nestedHistory{
+					{Timestamp: timestamp(baseTime.Add(time.Duration(i) * time.Second)), Event: "created"},
+					{Timestamp: timestamp(baseTime.Add(24*time.Hour + time.Duration(i)*time.Second)), Event: "paid"},
+				},
+			},
+		}
+	case "numeric":
+		values := make([]float64, 32)
+		minValue, maxValue, sum := math.Inf(1), math.Inf(-1), 0.0
+		for v := range values {
+			values[v] = float64(rng.Intn(100000)) / 1000
+			minValue = math.Min(minValue, values[v])
+			maxValue = math.Max(maxValue, values[v])
+			sum += values[v]
+		}
+		mean := sum / float64(len(values))
+		var variance float64
+		for _, value := range values {
+			variance += (value - mean) * (value - mean)
+		}
+		variance /= float64(len(values))
+		counts := make([]int, 16)
+		for c := range counts {
+			counts[c] = rng.Intn(100000)
+		}
+		return numericRecord{
+			ID:     i,
+			Values: values,
+			Counts: counts,
+			Stats:  numericStats{Min: minValue, Max: maxValue, Mean: mean, Stddev: math.Sqrt(variance)},
+		}
+	case "string":
+		slug := strings.ReplaceAll(randomWords(rng, 3), " ", "-")
+		return stringRecord{
+			ID:     i,
+			Title:  randomWords(rng, 8),
+			Body:   randomWords(rng, 80),
+			Author: fmt.Sprintf("Author %d", rng.Intn(500)),
+			URL:    fmt.Sprintf("https://example.com/articles/%d/%s", i, slug),
+			Tags:   []string{words[rng.Intn(len(words))], words[rng.Intn(len(words))], words[rng.Intn(len(words))]},
+		}
+	}
+	panic(fmt.Sprintf("unknown shape: %s", shape))
+}
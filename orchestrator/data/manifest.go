@@ -0,0 +1,234 @@
+package data
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// DefaultSeed is the seed the checked-in manifest was generated with.
+const DefaultSeed int64 = 1
+
+// ManifestFile is the name of the manifest inside the data directory.
+const ManifestFile = "manifest.json"
+
+// Manifest records the size and checksum of every generated dataset.
+type Manifest struct {
+	Seed  int64           `json:"seed"`
+	Files []ManifestEntry `json:"files"`
+}
+
+type ManifestEntry struct {
+	Name   string `json:"name"`
+	Bytes  int64  `json:"bytes"`
+	SHA256 string `json:"sha256"`
+}
+
+// Entry returns the manifest entry for a dataset.
+func (m *Manifest) Entry(name string) (*ManifestEntry, bool) {
+	for i := range m.Files {
+		if m.Files[i].Name == name {
+			return &m.Files[i], true
+		}
+	}
+	return nil, false
+}
+
+func (m *Manifest) set(entry ManifestEntry) {
+	if existing, ok := m.Entry(entry.Name); ok {
+		*existing = entry
+		return
+	}
+	m.Files = append(m.Files, entry)
+	sort.Slice(m.Files, func(i, j int) bool { return m.Files[i].Name < m.Files[j].Name })
+}
+
+// LoadManifest reads the manifest from dir. A missing manifest is not an
+// error; it returns nil.
+func LoadManifest(dir string) (*Manifest, error) {
+	content, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %v", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %v", err)
+	}
+	return &manifest, nil
+}
+
+func saveManifest(dir string, manifest *Manifest) error {
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %v", err)
+	}
+	return os.WriteFile(filepath.Join(dir, ManifestFile), append(content, '\n'), 0644)
+}
+
+// Generate writes the named datasets into dir and records them in the
+// manifest. Entries generated with a different seed are replaced, since the
+// manifest describes a single seed.
+func Generate(dir string, names []string, seed int64) (*Manifest, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %v", err)
+	}
+
+	manifest, err := LoadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	if manifest == nil || manifest.Seed != seed {
+		manifest = &Manifest{Seed: seed}
+	}
+
+	for _, name := range names {
+		dataset, ok := Lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown dataset: %s", name)
+		}
+
+		fmt.Printf("Generating %s (%s)...\n", dataset.Name, dataset.Description)
+		entry, err := generateFile(dir, dataset, seed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate %s: %v", name, err)
+		}
+		fmt.Printf("  ✓ %s: %.1fKB, sha256 %s\n", entry.Name, float64(entry.Bytes)/1024, entry.SHA256)
+		manifest.set(entry)
+	}
+
+	if err := saveManifest(dir, manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// generateFile writes a dataset through a temporary file so an interrupted
+// run never leaves a truncated dataset behind.
+func generateFile(dir string, dataset *Dataset, seed int64) (ManifestEntry, error) {
+	path := filepath.Join(dir, dataset.Name)
+	tmp, err := os.CreateTemp(dir, dataset.Name+".tmp*")
+	if err != nil {
+		return ManifestEntry{}, err
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	counter := &countingWriter{}
+	writer := bufio.NewWriter(io.MultiWriter(tmp, hash, counter))
+
+	// Each dataset gets its own stream so adding a dataset never changes the others
+	rng := rand.New(rand.NewSource(seed ^ int64(nameHash(dataset.Name))))
+	if err := dataset.generate(writer, rng); err != nil {
+		tmp.Close()
+		return ManifestEntry{}, err
+	}
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return ManifestEntry{}, err
+	}
+	if err := tmp.Close(); err != nil {
+		return ManifestEntry{}, err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return ManifestEntry{}, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return ManifestEntry{}, err
+	}
+
+	return ManifestEntry{
+		Name:   dataset.Name,
+		Bytes:  counter.n,
+		SHA256: hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
+
+// nameHash is FNV-1a over the dataset name
+func nameHash(name string) uint32 {
+	h := uint32(2166136261)
+	for i := 0; i < len(name); i++ {
+		h ^= uint32(name[i])
+		h *= 16777619
+	}
+	return h
+}
+
+// Verify checks a dataset file in dir against its manifest entry.
+func Verify(dir string, manifest *Manifest, name string) error {
+	entry, ok := manifest.Entry(name)
+	if !ok {
+		return fmt.Errorf("%s is not listed in the manifest", name)
+	}
+
+	file, err := os.Open(filepath.Join(dir, name))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", name, err)
+	}
+	if size != entry.Bytes {
+		return fmt.Errorf("%s is %d bytes, manifest expects %d", name, size, entry.Bytes)
+	}
+	if sum := hex.EncodeToString(hash.Sum(nil)); sum != entry.SHA256 {
+		return fmt.Errorf("%s has sha256 %s, manifest expects %s", name, sum, entry.SHA256)
+	}
+	return nil
+}
+
+// Ensure makes sure the named dataset exists in dir and matches the
+// manifest, generating it with the manifest's seed when it is missing.
+func Ensure(dir, name string) error {
+	if _, ok := Lookup(name); !ok {
+		return fmt.Errorf("unknown dataset: %s", name)
+	}
+
+	manifest, err := LoadManifest(dir)
+	if err != nil {
+		return err
+	}
+	seed := DefaultSeed
+	if manifest != nil {
+		seed = manifest.Seed
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, name)); os.IsNotExist(err) {
+		fmt.Printf("Test data %s is missing, generating it...\n", name)
+		manifest, err = Generate(dir, []string{name}, seed)
+		if err != nil {
+			return err
+		}
+	}
+
+	if manifest == nil {
+		return fmt.Errorf("no %s in %s, run 'benchmark-cli data generate'", ManifestFile, dir)
+	}
+	if err := Verify(dir, manifest, name); err != nil {
+		return fmt.Errorf("test data check failed: %v (run 'benchmark-cli data generate' to regenerate)", err)
+	}
+	return nil
+}
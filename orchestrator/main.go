@@ -1,15 +1,21 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"performance-benchmark-suite/orchestrator/cmd"
+	"performance-benchmark-suite/orchestrator/runner"
 )
 
 func main() {
 	if err := cmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		var interrupted *runner.InterruptedError
+		if errors.As(err, &interrupted) {
+			os.Exit(interrupted.ExitCode())
+		}
 		os.Exit(1)
 	}
 }
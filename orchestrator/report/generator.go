@@ -3,10 +3,12 @@ package report
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 
@@ -19,12 +21,58 @@ import (
 type Report struct {
 	Metadata Metadata          `json:"metadata"`
 	Results  []BenchmarkResult `json:"results"`
+	Scaling  []Scaling         `json:"scaling,omitempty"`
+}
+
+// Scaling shows how each technology's results change across the parameter
+// sets a test was swept over
+type Scaling struct {
+	Test       string         `json:"test"`
+	Parameters []string       `json:"parameters"`
+	Curves     []ScalingCurve `json:"curves"`
+}
+
+type ScalingCurve struct {
+	Tech   string         `json:"tech"`
+	Points []ScalingPoint `json:"points"`
+}
+
+// ScalingPoint is the result of one parameter set of a sweep
+type ScalingPoint struct {
+	Parameters map[string]string `json:"parameters"`
+	Metrics    Metrics           `json:"metrics"`
 }
 
 type Metadata struct {
 	ReportGeneratedAt string       `json:"reportGeneratedAt"`
 	SystemInfo        SystemInfo   `json:"systemInfo"`
 	ToolVersions      ToolVersions `json:"toolVersions"`
+	ConfigFiles       []string     `json:"configFiles,omitempty"`
+	Suite             string       `json:"suite,omitempty"`
+	Execution         *Execution   `json:"execution,omitempty"`
+	Journal           string       `json:"journal,omitempty"`
+	Log               string       `json:"log,omitempty"`
+}
+
+// Execution records the order the trials ran in, and the seed that produced
+// it for random order, so a session can be reproduced
+type Execution struct {
+	Order  string  `json:"order"`
+	Seed   *int64  `json:"seed,omitempty"`
+	Runs   int     `json:"runs"`
+	Trials []Trial `json:"trials"`
+
+	// Resumes counts the times the run was resumed from its journal
+	Resumes int `json:"resumes,omitempty"`
+}
+
+// Trial is one benchmark execution in the order it ran
+type Trial struct {
+	Tech  string            `json:"tech"`
+	Test  string            `json:"test"`
+	Run   int               `json:"run"`
+	Sweep map[string]string `json:"sweep,omitempty"`
+	Error string            `json:"error,omitempty"`
 }
 
 type SystemInfo struct {
@@ -40,10 +88,114 @@ type ToolVersions struct {
 }
 
 type BenchmarkResult struct {
-	Tech       string            `json:"tech"`
-	Test       string            `json:"test"`
-	Parameters map[string]string `json:"parameters"`
-	Metrics    Metrics           `json:"metrics"`
+	Tech         string            `json:"tech"`
+	Test         string            `json:"test"`
+	Parameters   map[string]string `json:"parameters"`
+	Metrics      Metrics           `json:"metrics"`
+	Digest       string            `json:"digest,omitempty"`
+	Verification string            `json:"verification,omitempty"`
+	IOMode       *IOMode           `json:"ioMode,omitempty"`
+	Workspace    *Workspace        `json:"workspace,omitempty"`
+	ColdStart    *ColdStart        `json:"coldStart,omitempty"`
+	Fixture      *Fixture          `json:"fixture,omitempty"`
+	Resources    *ResourceUsage    `json:"resources,omitempty"`
+	Sweep        map[string]string `json:"sweep,omitempty"`
+	Run          int               `json:"run,omitempty"`
+
+	ConcurrencySearch *ConcurrencySearch `json:"concurrencySearch,omitempty"`
+	Profiles          []ProfileArtifact  `json:"profiles,omitempty"`
+}
+
+// ProfileArtifact is a profile captured while the benchmark ran. Path is
+// relative to the directory holding the report; Process names the benchmark
+// that produced it, which is a fixture for client benchmarks.
+type ProfileArtifact struct {
+	Kind    string `json:"kind"`
+	Process string `json:"process"`
+	Path    string `json:"path"`
+}
+
+// ConcurrencySearch is the outcome of the SLO-driven concurrency search: the
+// highest client count within the SLO and every level measured on the way
+type ConcurrencySearch struct {
+	SLO   SLO                `json:"slo"`
+	Knee  int                `json:"knee"`
+	Curve []ConcurrencyLevel `json:"curve"`
+}
+
+type SLO struct {
+	P99Ms     float64 `json:"p99Ms"`
+	ErrorRate float64 `json:"errorRate"`
+}
+
+// ConcurrencyLevel is the throughput and latency measured at one client count
+type ConcurrencyLevel struct {
+	Clients           int     `json:"clients"`
+	Requests          int     `json:"requests"`
+	Errors            int     `json:"errors"`
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+	ErrorRate         float64 `json:"errorRate"`
+	LatencyAvgMs      float64 `json:"latencyAvgMs"`
+	LatencyP50Ms      float64 `json:"latencyP50Ms"`
+	LatencyP90Ms      float64 `json:"latencyP90Ms"`
+	LatencyP99Ms      float64 `json:"latencyP99Ms"`
+	WithinSLO         bool    `json:"withinSlo"`
+	Violation         string  `json:"violation,omitempty"`
+}
+
+// ResourceUsage is the exact resource usage the kernel accounted to a
+// benchmark process and the children it waited for, taken from wait4
+type ResourceUsage struct {
+	WallTimeMs                 float64 `json:"wallTimeMs"`
+	UserCPUMs                  float64 `json:"userCpuMs"`
+	SystemCPUMs                float64 `json:"systemCpuMs"`
+	MaxRSSMB                   float64 `json:"maxRssMB"`
+	MinorPageFaults            int64   `json:"minorPageFaults"`
+	MajorPageFaults            int64   `json:"majorPageFaults"`
+	VoluntaryContextSwitches   int64   `json:"voluntaryContextSwitches"`
+	InvoluntaryContextSwitches int64   `json:"involuntaryContextSwitches"`
+	BlockInputOps              int64   `json:"blockInputOps"`
+	BlockOutputOps             int64   `json:"blockOutputOps"`
+}
+
+// Fixture is the resource usage of a server a client benchmark depended on
+type Fixture struct {
+	Test          string  `json:"test"`
+	MaxMemoryMB   float64 `json:"maxMemoryMB"`
+	AvgCPUPercent float64 `json:"avgCpuPercent"`
+}
+
+// ColdStart holds the start-up times measured by spawning a server repeatedly
+type ColdStart struct {
+	Server          string       `json:"server"`
+	Prebuilt        bool         `json:"prebuilt,omitempty"`
+	Samples         int          `json:"samples"`
+	SpawnToListen   Distribution `json:"spawnToListen"`
+	SpawnToFirst200 Distribution `json:"spawnToFirst200"`
+}
+
+// Distribution summarizes repeated timing samples in milliseconds
+type Distribution struct {
+	AvgMs float64 `json:"avgMs"`
+	MinMs float64 `json:"minMs"`
+	MaxMs float64 `json:"maxMs"`
+	P50Ms float64 `json:"p50Ms"`
+	P90Ms float64 `json:"p90Ms"`
+	P95Ms float64 `json:"p95Ms"`
+	P99Ms float64 `json:"p99Ms"`
+}
+
+// Workspace describes where a benchmark wrote its output
+type Workspace struct {
+	Dir        string `json:"dir"`
+	Filesystem string `json:"filesystem"`
+}
+
+// IOMode records how a file benchmark performed its I/O
+type IOMode struct {
+	Sync   string `json:"sync,omitempty"`
+	Direct bool   `json:"direct"`
+	Cache  string `json:"cache,omitempty"`
 }
 
 type Metrics struct {
@@ -61,14 +213,47 @@ type Metrics struct {
 	MaxRequestsPerSecond float64 `json:"maxRequestsPerSecond,omitempty"`
 	ConcurrencyThreshold float64 `json:"concurrencyThreshold,omitempty"`
 	BuildTimeMs          float64 `json:"buildTimeMs,omitempty"`
+	MessagesPerSecond    float64 `json:"messagesPerSecond,omitempty"`
+	IOPS                 float64 `json:"iops,omitempty"`
+	MBPerSecond          float64 `json:"mbPerSecond,omitempty"`
+	ConnectionSetupAvgMs float64 `json:"connectionSetupAvgMs,omitempty"`
+	ConnectionSetupP99Ms float64 `json:"connectionSetupP99Ms,omitempty"`
+	MemoryPerConnKB      float64 `json:"memoryPerConnectionKB,omitempty"`
+	Errors               int     `json:"errors,omitempty"`
 	MaxMemoryMB          float64 `json:"maxMemoryMB"`
 	AvgCPUPercent        float64 `json:"avgCpuPercent"`
 }
 
-type Generator struct{}
+type Generator struct {
+	config    *config.Config
+	suite     string
+	execution *Execution
+	journal   string
+	log       string
+}
 
-func NewGenerator() *Generator {
-	return &Generator{}
+func NewGenerator(cfg *config.Config) *Generator {
+	return &Generator{config: cfg}
+}
+
+// SetSuite records the suite the results were produced by.
+func (g *Generator) SetSuite(name string) {
+	g.suite = name
+}
+
+// SetExecution records the order the results were produced in.
+func (g *Generator) SetExecution(execution *Execution) {
+	g.execution = execution
+}
+
+// SetJournal records the journal the results were collected in.
+func (g *Generator) SetJournal(path string) {
+	g.journal = path
+}
+
+// SetLog records the log file of the run that wrote the report.
+func (g *Generator) SetLog(path string) {
+	g.log = path
 }
 
 func (g *Generator) GenerateReport(results []BenchmarkResult, outputDir string) (string, error) {
@@ -95,8 +280,14 @@ func (g *Generator) GenerateReport(results []BenchmarkResult, outputDir string)
 			ReportGeneratedAt: time.Now().UTC().Format(time.RFC3339),
 			SystemInfo:        systemInfo,
 			ToolVersions:      toolVersions,
+			ConfigFiles:       g.config.Files,
+			Suite:             g.suite,
+			Execution:         g.execution,
+			Journal:           g.journal,
+			Log:               g.log,
 		},
 		Results: results,
+		Scaling: scalingCurves(results),
 	}
 
 	// Generate filename with timestamp
@@ -116,10 +307,66 @@ func (g *Generator) GenerateReport(results []BenchmarkResult, outputDir string)
 	if err := encoder.Encode(report); err != nil {
 		return "", fmt.Errorf("failed to encode report: %v", err)
 	}
+	slog.Info("report written", "path", filepath, "results", len(results), "scaling", len(report.Scaling))
 
 	return filepath, nil
 }
 
+// scalingCurves groups swept results by test and then by technology, keeping
+// the points in the order they ran.
+func scalingCurves(results []BenchmarkResult) []Scaling {
+	var scaling []Scaling
+	tests := make(map[string]int)
+	for _, result := range results {
+		if len(result.Sweep) == 0 {
+			continue
+		}
+		i, ok := tests[result.Test]
+		if !ok {
+			i = len(scaling)
+			tests[result.Test] = i
+			scaling = append(scaling, Scaling{Test: result.Test})
+		}
+		view := &scaling[i]
+
+		for key := range result.Sweep {
+			if !containsString(view.Parameters, key) {
+				view.Parameters = append(view.Parameters, key)
+			}
+		}
+
+		curve := -1
+		for j := range view.Curves {
+			if view.Curves[j].Tech == result.Tech {
+				curve = j
+				break
+			}
+		}
+		if curve < 0 {
+			curve = len(view.Curves)
+			view.Curves = append(view.Curves, ScalingCurve{Tech: result.Tech})
+		}
+		view.Curves[curve].Points = append(view.Curves[curve].Points, ScalingPoint{
+			Parameters: result.Sweep,
+			Metrics:    result.Metrics,
+		})
+	}
+
+	for i := range scaling {
+		sort.Strings(scaling[i].Parameters)
+	}
+	return scaling
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
 func (g *Generator) gatherSystemInfo() (SystemInfo, error) {
 	// Get host info
 	hostInfo, err := host.Info()
@@ -143,19 +390,15 @@ func (g *Generator) gatherSystemInfo() (SystemInfo, error) {
 }
 
 func (g *Generator) gatherToolVersions() (ToolVersions, error) {
-	// Load configuration to get version commands
-	cfg, err := config.GetConfig()
-	if err != nil {
-		return ToolVersions{Versions: make(map[string]string)}, nil
-	}
-
 	versions := make(map[string]string)
 
 	// Get versions for all configured technologies
-	for techKey, tech := range cfg.Technologies {
+	for techKey, tech := range g.config.Technologies {
 		if len(tech.VersionCommand) > 0 {
 			if output, err := exec.Command(tech.VersionCommand[0], tech.VersionCommand[1:]...).Output(); err == nil {
 				versions[techKey] = strings.TrimSpace(string(output))
+			} else {
+				slog.Debug("version command failed", "tech", techKey, "command", strings.Join(tech.VersionCommand, " "), "error", err)
 			}
 		}
 	}
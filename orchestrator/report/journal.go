@@ -0,0 +1,174 @@
+package report
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Journal is an append-only record of a run in JSON lines, written as each
+// trial finishes so that a crash loses at most the trial in progress.
+type Journal struct {
+	Path string
+	file *os.File
+}
+
+// JournalEntry is one line of a journal: either the start of a session with
+// the plan it set out to run, or a finished trial with its result or error.
+type JournalEntry struct {
+	Session *JournalSession  `json:"session,omitempty"`
+	Trial   *JournalTrial    `json:"trial,omitempty"`
+	Result  *BenchmarkResult `json:"result,omitempty"`
+}
+
+// JournalSession is an invocation of run that wrote to the journal. Plan is
+// every trial of the run in execution order; resumed sessions repeat it.
+type JournalSession struct {
+	StartedAt string         `json:"startedAt"`
+	Order     string         `json:"order"`
+	Seed      *int64         `json:"seed,omitempty"`
+	Runs      int            `json:"runs"`
+	Suite     string         `json:"suite,omitempty"`
+	Plan      []JournalTrial `json:"plan"`
+}
+
+// JournalTrial is a trial with the full parameters it runs with
+type JournalTrial struct {
+	Trial
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// Key identifies a trial by technology, test, parameters and run, so a
+// resumed run can tell which trials the journal already completed.
+func (t JournalTrial) Key() string {
+	pairs := make([]string, 0, len(t.Params))
+	for key, value := range t.Params {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return fmt.Sprintf("%s/%s?%s#%d", t.Tech, t.Test, strings.Join(pairs, "&"), t.Run)
+}
+
+// CreateJournal starts a new journal in outputDir. The name carries a random
+// suffix after the timestamp, so runs started within the same second never
+// share a journal.
+func CreateJournal(outputDir string) (*Journal, error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %v", err)
+	}
+	timestamp := time.Now().UTC().Format("2006-01-02T15-04-05Z")
+	file, err := os.CreateTemp(outputDir, fmt.Sprintf("journal_%s_*.jsonl", timestamp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create journal: %v", err)
+	}
+	return &Journal{Path: file.Name(), file: file}, nil
+}
+
+// ReadJournal reads the entries of an existing journal without changing it.
+// A last line left incomplete by a crash is skipped.
+func ReadJournal(path string) ([]JournalEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %v", err)
+	}
+	defer file.Close()
+
+	entries, _, err := readJournal(file, path)
+	return entries, err
+}
+
+// OpenJournal reads an existing journal and opens it for appending. A last
+// line left incomplete by a crash is dropped from the file.
+func OpenJournal(path string) (*Journal, []JournalEntry, error) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open journal: %v", err)
+	}
+
+	entries, valid, err := readJournal(file, path)
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	if err := file.Truncate(valid); err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("failed to repair journal: %v", err)
+	}
+	if _, err := file.Seek(valid, io.SeekStart); err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("failed to repair journal: %v", err)
+	}
+	return &Journal{Path: path, file: file}, entries, nil
+}
+
+// readJournal decodes the complete lines of a journal and returns them with
+// the number of bytes they take up.
+func readJournal(r io.Reader, path string) ([]JournalEntry, int64, error) {
+	var entries []JournalEntry
+	var valid int64
+	reader := bufio.NewReader(r)
+	for lineNumber := 1; ; lineNumber++ {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// Anything after the last newline is a write cut short
+			if len(line) > 0 {
+				slog.Warn("ignoring incomplete last line of journal", "path", path, "line", lineNumber, "bytes", len(line))
+			}
+			return entries, valid, nil
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read journal: %v", err)
+		}
+		var entry JournalEntry
+		if len(bytes.TrimSpace(line)) > 0 {
+			if err := json.Unmarshal(line, &entry); err != nil {
+				return nil, 0, fmt.Errorf("journal %s line %d: %v", path, lineNumber, err)
+			}
+			entries = append(entries, entry)
+		}
+		valid += int64(len(line))
+	}
+}
+
+// LatestAttempts keeps the last attempt of every trial, by Key, in the order
+// the attempts were made. A trial retried by a resumed run is listed once.
+func LatestAttempts(trials []JournalTrial) []Trial {
+	last := make(map[string]int, len(trials))
+	for i, trial := range trials {
+		last[trial.Key()] = i
+	}
+	var latest []Trial
+	for i, trial := range trials {
+		if last[trial.Key()] == i {
+			latest = append(latest, trial.Trial)
+		}
+	}
+	return latest
+}
+
+// Append writes entry as one line and flushes it to disk.
+func (j *Journal) Append(entry JournalEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode journal entry: %v", err)
+	}
+	if _, err := j.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write journal: %v", err)
+	}
+	if err := j.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync journal: %v", err)
+	}
+	return nil
+}
+
+func (j *Journal) Close() error {
+	return j.file.Close()
+}
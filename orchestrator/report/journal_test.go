@@ -0,0 +1,203 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestOpenJournal(t *testing.T) {
+	session := `{"session":{"startedAt":"2026-01-01T00:00:00Z","order":"sequential","runs":1,"plan":[]}}` + "\n"
+	trial := `{"trial":{"tech":"go","test":"json_write","run":1}}` + "\n"
+
+	tests := []struct {
+		name    string
+		content string
+		entries int
+		size    int
+		wantErr bool
+	}{
+		{name: "complete", content: session + trial, entries: 2, size: len(session + trial)},
+		{name: "torn last line", content: session + trial + `{"trial":{"te`, entries: 2, size: len(session + trial)},
+		{name: "torn only line", content: `{"sess`, entries: 0, size: 0},
+		{name: "blank lines", content: session + "\n" + trial, entries: 2, size: len(session + "\n" + trial)},
+		{name: "empty", content: "", entries: 0, size: 0},
+		{name: "corrupt complete line", content: session + "{not json}\n" + trial, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "journal.jsonl")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			journal, entries, err := OpenJournal(path)
+			if tt.wantErr {
+				if err == nil {
+					journal.Close()
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("OpenJournal: %v", err)
+			}
+			defer journal.Close()
+
+			if len(entries) != tt.entries {
+				t.Errorf("got %d entries, want %d", len(entries), tt.entries)
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Size() != int64(tt.size) {
+				t.Errorf("file is %d bytes after opening, want %d", info.Size(), tt.size)
+			}
+
+			// Appending after the repair must leave a journal that reads back whole
+			if err := journal.Append(JournalEntry{Trial: &JournalTrial{Trial: Trial{Tech: "node", Test: "json_write", Run: 1}}}); err != nil {
+				t.Fatalf("Append: %v", err)
+			}
+			journal.Close()
+
+			reopened, entries, err := OpenJournal(path)
+			if err != nil {
+				t.Fatalf("reopening: %v", err)
+			}
+			reopened.Close()
+			if len(entries) != tt.entries+1 {
+				t.Errorf("got %d entries after appending, want %d", len(entries), tt.entries+1)
+			}
+			if last := entries[len(entries)-1]; last.Trial == nil || last.Trial.Tech != "node" {
+				t.Errorf("last entry is %+v, want the appended node trial", last)
+			}
+		})
+	}
+}
+
+func TestJournalTrialKey(t *testing.T) {
+	base := JournalTrial{
+		Trial:  Trial{Tech: "go", Test: "file_write", Run: 1},
+		Params: map[string]string{"size": "1024", "sync": "none"},
+	}
+
+	tests := []struct {
+		name  string
+		trial JournalTrial
+		same  bool
+	}{
+		{name: "identical", trial: base, same: true},
+		{
+			name: "params in another order",
+			trial: JournalTrial{
+				Trial:  Trial{Tech: "go", Test: "file_write", Run: 1},
+				Params: map[string]string{"sync": "none", "size": "1024"},
+			},
+			same: true,
+		},
+		{
+			name: "sweep is not part of the key",
+			trial: JournalTrial{
+				Trial:  Trial{Tech: "go", Test: "file_write", Run: 1, Sweep: map[string]string{"size": "1024"}},
+				Params: map[string]string{"size": "1024", "sync": "none"},
+			},
+			same: true,
+		},
+		{
+			name: "other run",
+			trial: JournalTrial{
+				Trial:  Trial{Tech: "go", Test: "file_write", Run: 2},
+				Params: base.Params,
+			},
+		},
+		{
+			name: "other tech",
+			trial: JournalTrial{
+				Trial:  Trial{Tech: "node", Test: "file_write", Run: 1},
+				Params: base.Params,
+			},
+		},
+		{
+			name: "other param value",
+			trial: JournalTrial{
+				Trial:  Trial{Tech: "go", Test: "file_write", Run: 1},
+				Params: map[string]string{"size": "65536", "sync": "none"},
+			},
+		},
+		{
+			name: "fewer params",
+			trial: JournalTrial{
+				Trial:  Trial{Tech: "go", Test: "file_write", Run: 1},
+				Params: map[string]string{"size": "1024"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if same := tt.trial.Key() == base.Key(); same != tt.same {
+				t.Errorf("key %q vs %q: same = %v, want %v", tt.trial.Key(), base.Key(), same, tt.same)
+			}
+		})
+	}
+}
+
+func TestReadJournal(t *testing.T) {
+	content := `{"session":{"startedAt":"2026-01-01T00:00:00Z","order":"sequential","runs":1,"plan":[]}}` + "\n" + `{"trial":{"te`
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := ReadJournal(path)
+	if err != nil {
+		t.Fatalf("ReadJournal: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("got %d entries, want 1", len(entries))
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != content {
+		t.Errorf("ReadJournal changed the file to %q", data)
+	}
+}
+
+func TestCreateJournalUniqueNames(t *testing.T) {
+	dir := t.TempDir()
+	seen := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		journal, err := CreateJournal(dir)
+		if err != nil {
+			t.Fatalf("CreateJournal: %v", err)
+		}
+		journal.Close()
+		if seen[journal.Path] {
+			t.Errorf("journal %s was created twice", journal.Path)
+		}
+		seen[journal.Path] = true
+	}
+}
+
+func TestLatestAttempts(t *testing.T) {
+	attempt := func(tech string, run int, size string) JournalTrial {
+		return JournalTrial{Trial: Trial{Tech: tech, Test: "file_write", Run: run}, Params: map[string]string{"size": size}}
+	}
+	trials := []JournalTrial{
+		attempt("go", 1, "1024"),
+		attempt("node", 1, "1024"),
+		attempt("go", 1, "65536"),
+		// A resumed run retries the node trial that failed
+		attempt("node", 1, "1024"),
+	}
+
+	want := []Trial{trials[0].Trial, trials[2].Trial, trials[3].Trial}
+	if got := LatestAttempts(trials); !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %+v\nwant %+v", got, want)
+	}
+}
@@ -0,0 +1,345 @@
+package runner
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"performance-benchmark-suite/orchestrator/report"
+)
+
+// coldStartPollInterval is how long the prober waits between attempts while
+// the server is starting, well below a millisecond so it barely adds to the
+// measured time.
+const coldStartPollInterval = 100 * time.Microsecond
+
+// coldStartSample is one measured server start.
+type coldStartSample struct {
+	listen  time.Duration
+	firstOK time.Duration
+}
+
+// coldStartExecutor repeatedly spawns a technology's server command and
+// measures, from the moment the process is spawned, how long it takes until
+// the port accepts connections and until the health endpoint returns 200.
+type coldStartExecutor struct {
+	params     map[string]string
+	iterations int
+	warmup     int
+	timeout    time.Duration
+	target     string
+	command    []string
+	prebuilt   bool
+	buildDir   string
+	port       int
+	addr       string
+}
+
+func (e *coldStartExecutor) Prepare(run *BenchmarkRun) error {
+	slog.Info("starting cold start benchmark", "tech", run.Tech, "test", run.Test)
+	if err := e.configure(run); err != nil {
+		return err
+	}
+	if err := e.resolveServer(run); err != nil {
+		return err
+	}
+	if portOpen(e.addr) {
+		return fmt.Errorf("port %d is already in use, stop whatever is listening on it first", e.port)
+	}
+
+	// Spawning `go run` would time the go tool building the server on every
+	// start, so the server is built once and its binary spawned instead
+	if buildArgs, runArgs, ok := splitGoRun(e.command); ok {
+		var err error
+		if e.buildDir, err = os.MkdirTemp("", "cold-start-"); err != nil {
+			return fmt.Errorf("failed to create build directory: %v", err)
+		}
+		binary := filepath.Join(e.buildDir, "server"+exeSuffix())
+		if err := run.runner.goBuild(binary, buildArgs); err != nil {
+			return err
+		}
+		e.command = append([]string{binary}, runArgs...)
+		e.prebuilt = true
+	}
+	return nil
+}
+
+// configure resolves the sampling parameters for both Prepare and Plan.
+func (e *coldStartExecutor) configure(run *BenchmarkRun) error {
+	e.params = mergeParams(run.Benchmark.DefaultParams, run.Params)
+
+	var err error
+	if e.iterations, err = paramInt(e.params, "iterations", 10); err != nil {
+		return err
+	}
+	if e.warmup, err = paramInt(e.params, "warmup", 1); err != nil {
+		return err
+	}
+	if e.timeout, err = paramDuration(e.params, "timeout", 30*time.Second); err != nil {
+		return err
+	}
+	if e.iterations < 1 || e.warmup < 0 {
+		return fmt.Errorf("iterations must be positive and warmup not negative")
+	}
+	return nil
+}
+
+// resolveServer finds the server command to spawn and the port it listens on.
+func (e *coldStartExecutor) resolveServer(run *BenchmarkRun) error {
+	e.target = run.Benchmark.Server
+	if e.target == "" {
+		e.target = "http_server"
+	}
+	server, err := run.runner.config.GetBenchmark(run.Tech, e.target)
+	if err != nil {
+		return fmt.Errorf("failed to get server config: %v", err)
+	}
+	if server.Type != "server" {
+		return fmt.Errorf("%s - %s is not a server benchmark", run.Tech, e.target)
+	}
+	if len(server.Command) == 0 {
+		return fmt.Errorf("%s - %s has no command to spawn", run.Tech, e.target)
+	}
+	e.command = server.Command
+
+	e.port = run.Benchmark.Port
+	if e.port == 0 {
+		e.port = 3000
+	}
+	e.addr = fmt.Sprintf("127.0.0.1:%d", e.port)
+	return nil
+}
+
+func (e *coldStartExecutor) Plan(run *BenchmarkRun, planned *PlannedTrial) {
+	if err := e.configure(run); err != nil {
+		planned.skip(SkipInvalidParams, "%v", err)
+	}
+	if err := e.resolveServer(run); err != nil {
+		planned.skip(SkipUnsupported, "%v", err)
+		return
+	}
+
+	starts := e.iterations + e.warmup
+	if buildArgs, _, ok := splitGoRun(e.command); ok {
+		buildCmd := append([]string{"go", "build", "-o", plannedBuildDir + "server"}, buildArgs...)
+		planned.Commands = append(planned.Commands, PlannedCommand{Role: "build", Args: buildCmd, Dir: run.runner.projectRoot})
+		planned.Client = fmt.Sprintf("spawns the built %s binary %d times (%d warmup) and probes it until the first 200", e.target, starts, e.warmup)
+	} else {
+		planned.Commands = append(planned.Commands, PlannedCommand{Role: "spawn", Args: e.command, Dir: run.runner.projectRoot})
+		planned.Client = fmt.Sprintf("spawns %s %d times (%d warmup) and probes it until the first 200", e.target, starts, e.warmup)
+	}
+	planned.Ports = []int{e.port}
+}
+
+// Start launches nothing: starting the server is what Measure times, once
+// per sample.
+func (e *coldStartExecutor) Start(run *BenchmarkRun) error {
+	return nil
+}
+
+func (e *coldStartExecutor) Measure(run *BenchmarkRun) (*report.BenchmarkResult, error) {
+	tech := run.Tech
+	slog.Info("spawning server", "tech", tech, "server", e.target, "iterations", e.iterations, "warmup", e.warmup)
+
+	var listenTimes, firstOKTimes []time.Duration
+	for i := 0; i < e.warmup+e.iterations; i++ {
+		sample, err := run.runner.coldStartOnce(e.command, e.addr, e.timeout)
+		if err != nil {
+			return nil, fmt.Errorf("cold start %d failed: %v", i+1, err)
+		}
+
+		if i < e.warmup {
+			slog.Debug("cold start warmup", "tech", tech, "warmup", i+1, "listenMs", durationMs(sample.listen), "firstOkMs", durationMs(sample.firstOK))
+			continue
+		}
+		slog.Debug("cold start sample", "tech", tech, "run", i+1-e.warmup, "listenMs", durationMs(sample.listen), "firstOkMs", durationMs(sample.firstOK))
+		listenTimes = append(listenTimes, sample.listen)
+		firstOKTimes = append(firstOKTimes, sample.firstOK)
+	}
+
+	listen := summarizeDurations(listenTimes)
+	firstOK := summarizeDurations(firstOKTimes)
+	slog.Info("cold start completed", "tech", tech, "listenP50Ms", listen.P50Ms, "firstOkP50Ms", firstOK.P50Ms, "firstOkP99Ms", firstOK.P99Ms)
+
+	return &report.BenchmarkResult{
+		Tech:       tech,
+		Test:       run.Test,
+		Parameters: e.params,
+		Metrics: report.Metrics{
+			ColdStartTimeMs: firstOK.P50Ms,
+		},
+		ColdStart: &report.ColdStart{
+			Server:          e.target,
+			Prebuilt:        e.prebuilt,
+			Samples:         e.iterations,
+			SpawnToListen:   toDistribution(listen),
+			SpawnToFirst200: toDistribution(firstOK),
+		},
+	}, nil
+}
+
+// Teardown removes the prebuilt server. Every sample stops the server it
+// started itself.
+func (e *coldStartExecutor) Teardown(run *BenchmarkRun) {
+	if e.buildDir != "" {
+		os.RemoveAll(e.buildDir)
+	}
+}
+
+// goBuildValueFlags are the build flags of `go run` that take their value
+// as the next argument when it is not given with =.
+var goBuildValueFlags = map[string]bool{
+	"C": true, "p": true, "asmflags": true, "buildmode": true, "buildvcs": true, "compiler": true,
+	"covermode": true, "coverpkg": true, "exec": true, "gccgoflags": true, "gcflags": true,
+	"installsuffix": true, "ldflags": true, "mod": true, "modfile": true, "overlay": true, "pgo": true,
+	"pkgdir": true, "tags": true, "toolexec": true,
+}
+
+// splitGoRun splits a `go run [flags] <package or .go files> [args]` command
+// into the arguments that build it and the arguments the program runs with.
+// ok is false for any other command.
+func splitGoRun(command []string) (buildArgs, runArgs []string, ok bool) {
+	if len(command) < 3 || command[0] != "go" || command[1] != "run" {
+		return nil, nil, false
+	}
+	i := 2
+	for i < len(command) && strings.HasPrefix(command[i], "-") {
+		if goBuildValueFlags[strings.TrimLeft(command[i], "-")] {
+			i++
+		}
+		i++
+	}
+	if i >= len(command) {
+		return nil, nil, false
+	}
+	if strings.HasSuffix(command[i], ".go") {
+		for i < len(command) && strings.HasSuffix(command[i], ".go") {
+			i++
+		}
+	} else {
+		i++
+	}
+	return command[2:i], command[i:], true
+}
+
+// goBuild builds the package or files given by buildArgs into binary.
+func (r *Runner) goBuild(binary string, buildArgs []string) error {
+	slog.Info("building Go binary", "binary", binary, "args", strings.Join(buildArgs, " "))
+	cmd := exec.Command("go", append([]string{"build", "-o", binary}, buildArgs...)...)
+	cmd.Dir = r.projectRoot
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to build Go binary: %v, output: %s", err, output)
+	}
+	return nil
+}
+
+func exeSuffix() string {
+	if runtime.GOOS == "windows" {
+		return ".exe"
+	}
+	return ""
+}
+
+// coldStartOnce spawns the server, probes it until it answers and then kills
+// it, waiting until the port is free again for the next start.
+func (r *Runner) coldStartOnce(command []string, addr string, timeout time.Duration) (coldStartSample, error) {
+	var sample coldStartSample
+
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Dir = r.projectRoot
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	setProcessGroup(cmd)
+
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		return sample, fmt.Errorf("failed to start server: %v", err)
+	}
+	r.interrupt.started(cmd)
+
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+	defer func() {
+		killProcessGroup(cmd)
+		<-exited
+		r.interrupt.stopped(cmd)
+		waitPortClosed(addr, timeout)
+	}()
+
+	deadline := start.Add(timeout)
+	client := &http.Client{Timeout: time.Second}
+	healthURL := fmt.Sprintf("http://%s/health", addr)
+
+	for {
+		select {
+		case err := <-exited:
+			exited <- err
+			return sample, fmt.Errorf("server exited during startup (%v), stderr: %s", err, stderr.String())
+		default:
+		}
+		if time.Now().After(deadline) {
+			return sample, fmt.Errorf("server did not answer within %v, stderr: %s", timeout, stderr.String())
+		}
+
+		if sample.listen == 0 {
+			conn, err := net.DialTimeout("tcp", addr, 100*time.Millisecond)
+			if err != nil {
+				time.Sleep(coldStartPollInterval)
+				continue
+			}
+			sample.listen = time.Since(start)
+			conn.Close()
+		}
+
+		resp, err := client.Get(healthURL)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				sample.firstOK = time.Since(start)
+				return sample, nil
+			}
+		}
+		time.Sleep(coldStartPollInterval)
+	}
+}
+
+func portOpen(addr string) bool {
+	conn, err := net.DialTimeout("tcp", addr, 100*time.Millisecond)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// waitPortClosed waits for a killed server's children to let go of the port.
+func waitPortClosed(addr string, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for portOpen(addr) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d.Nanoseconds()) / 1e6
+}
+
+func toDistribution(summary LatencySummary) report.Distribution {
+	return report.Distribution{
+		AvgMs: summary.AvgMs,
+		MinMs: summary.MinMs,
+		MaxMs: summary.MaxMs,
+		P50Ms: summary.P50Ms,
+		P90Ms: summary.P90Ms,
+		P95Ms: summary.P95Ms,
+		P99Ms: summary.P99Ms,
+	}
+}
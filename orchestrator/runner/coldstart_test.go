@@ -0,0 +1,165 @@
+package runner
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"performance-benchmark-suite/orchestrator/config"
+)
+
+func TestSplitGoRun(t *testing.T) {
+	tests := []struct {
+		name      string
+		command   []string
+		buildArgs []string
+		runArgs   []string
+		ok        bool
+	}{
+		{
+			name:      "package",
+			command:   []string{"go", "run", "./benchmarks/go/http_server"},
+			buildArgs: []string{"./benchmarks/go/http_server"},
+			runArgs:   []string{},
+			ok:        true,
+		},
+		{
+			name:      "package with flags and arguments",
+			command:   []string{"go", "run", "-tags", "netgo", "./benchmarks/go/http_server", "--port=3000"},
+			buildArgs: []string{"-tags", "netgo", "./benchmarks/go/http_server"},
+			runArgs:   []string{"--port=3000"},
+			ok:        true,
+		},
+		{
+			name:      "files",
+			command:   []string{"go", "run", "main.go", "handlers.go", "--port=3000"},
+			buildArgs: []string{"main.go", "handlers.go"},
+			runArgs:   []string{"--port=3000"},
+			ok:        true,
+		},
+		{
+			name:      "boolean flag",
+			command:   []string{"go", "run", "-race", "."},
+			buildArgs: []string{"-race", "."},
+			runArgs:   []string{},
+			ok:        true,
+		},
+		{
+			name:      "flag value given with =",
+			command:   []string{"go", "run", "-ldflags=-s -w", "./benchmarks/go/http_server"},
+			buildArgs: []string{"-ldflags=-s -w", "./benchmarks/go/http_server"},
+			runArgs:   []string{},
+			ok:        true,
+		},
+		{name: "only flags", command: []string{"go", "run", "-race"}},
+		{name: "flag missing its value", command: []string{"go", "run", "-tags"}},
+		{name: "no package", command: []string{"go", "run"}},
+		{name: "go build", command: []string{"go", "build", "./benchmarks/go/http_server"}},
+		{name: "other command", command: []string{"node", "benchmarks/node/http_server/index.js"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buildArgs, runArgs, ok := splitGoRun(tt.command)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if !reflect.DeepEqual(buildArgs, tt.buildArgs) || !reflect.DeepEqual(runArgs, tt.runArgs) {
+				t.Errorf("got build %q run %q, want build %q run %q", buildArgs, runArgs, tt.buildArgs, tt.runArgs)
+			}
+		})
+	}
+}
+
+func TestColdStartConfigure(t *testing.T) {
+	tests := []struct {
+		name       string
+		params     map[string]string
+		iterations int
+		warmup     int
+		timeout    time.Duration
+		wantErr    bool
+	}{
+		{name: "defaults", iterations: 10, warmup: 1, timeout: 30 * time.Second},
+		{name: "no warmup", params: map[string]string{"warmup": "0", "iterations": "3"}, iterations: 3, timeout: 30 * time.Second},
+		{name: "no iterations", params: map[string]string{"iterations": "0"}, wantErr: true},
+		{name: "negative warmup", params: map[string]string{"warmup": "-1"}, wantErr: true},
+		{name: "invalid timeout", params: map[string]string{"timeout": "later"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &coldStartExecutor{}
+			err := e.configure(&BenchmarkRun{Tech: "go", Test: "cold_start", Benchmark: &config.Benchmark{}, Params: tt.params})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("configure: %v", err)
+			}
+			if e.iterations != tt.iterations || e.warmup != tt.warmup || e.timeout != tt.timeout {
+				t.Errorf("got %d iterations, %d warmup and %v timeout, want %d, %d and %v",
+					e.iterations, e.warmup, e.timeout, tt.iterations, tt.warmup, tt.timeout)
+			}
+		})
+	}
+}
+
+func TestColdStartResolveServer(t *testing.T) {
+	r := &Runner{config: &config.Config{Technologies: map[string]config.Technology{
+		"go": {Benchmarks: map[string]config.Benchmark{
+			"http_server": {Type: "server", Command: []string{"go", "run", "./benchmarks/go/http_server"}},
+			"json_server": {Type: "server", Command: []string{"go", "run", "./benchmarks/go/json_server"}},
+			"no_command":  {Type: "server"},
+			"json_read":   {Type: "benchmark", Command: []string{"go", "run", "./benchmarks/go/json_read"}},
+		}},
+	}}}
+
+	tests := []struct {
+		name      string
+		benchmark config.Benchmark
+		server    string
+		command   []string
+		addr      string
+		wantErr   bool
+	}{
+		{
+			name:    "default server",
+			server:  "http_server",
+			command: []string{"go", "run", "./benchmarks/go/http_server"},
+			addr:    "127.0.0.1:3000",
+		},
+		{
+			name:      "configured server and port",
+			benchmark: config.Benchmark{Server: "json_server", Port: 3002},
+			server:    "json_server",
+			command:   []string{"go", "run", "./benchmarks/go/json_server"},
+			addr:      "127.0.0.1:3002",
+		},
+		{name: "missing server", benchmark: config.Benchmark{Server: "missing"}, wantErr: true},
+		{name: "not a server", benchmark: config.Benchmark{Server: "json_read"}, wantErr: true},
+		{name: "server without command", benchmark: config.Benchmark{Server: "no_command"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &coldStartExecutor{}
+			err := e.resolveServer(&BenchmarkRun{Tech: "go", Test: "cold_start", Benchmark: &tt.benchmark, runner: r})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveServer: %v", err)
+			}
+			if e.target != tt.server || !reflect.DeepEqual(e.command, tt.command) || e.addr != tt.addr {
+				t.Errorf("got %s %q on %s, want %s %q on %s", e.target, e.command, e.addr, tt.server, tt.command, tt.addr)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package runner
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"performance-benchmark-suite/orchestrator/data"
+)
+
+// requiredDatasets works out which test_data files a benchmark will read: the
+// file named by its file parameter, or the payload shape file when only a
+// shape is given. Paths outside test_data are the caller's responsibility.
+func requiredDatasets(test string, defaults, overrides map[string]string) []string {
+	params := mergeParams(defaults, overrides)
+	// An explicit shape overrides the default file, as it does in the benchmarks
+	if _, ok := overrides["shape"]; ok && overrides["file"] == "" {
+		delete(params, "file")
+	}
+
+	var name string
+	if file := params["file"]; file != "" {
+		if filepath.Dir(filepath.Clean(file)) != "test_data" {
+			return nil
+		}
+		name = filepath.Base(file)
+	} else if shape := params["shape"]; shape != "" && shape != "items" {
+		name = fmt.Sprintf("shape_%s.json", shape)
+		if strings.HasSuffix(test, "_lines") {
+			name = fmt.Sprintf("shape_%s_lines.json", shape)
+		}
+	}
+
+	if _, ok := data.Lookup(name); !ok {
+		return nil
+	}
+	return []string{name}
+}
+
+// ensureTestData verifies, or generates when missing, the test data a
+// benchmark reads before it starts.
+func (r *Runner) ensureTestData(tech, test string, params map[string]string) error {
+	benchmark, err := r.config.GetBenchmark(tech, test)
+	if err != nil {
+		return fmt.Errorf("failed to get benchmark config: %v", err)
+	}
+
+	dir := filepath.Join(r.projectRoot, "test_data")
+	for _, name := range requiredDatasets(test, benchmark.DefaultParams, params) {
+		if err := data.Ensure(dir, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,334 @@
+package runner
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"performance-benchmark-suite/orchestrator/data"
+
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/load"
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+// Check outcomes reported by Doctor
+const (
+	CheckPass = "pass"
+	CheckWarn = "warn"
+	CheckFail = "fail"
+)
+
+// lowDiskSpace is the free space below which a directory gets a warning
+const lowDiskSpace = 1 << 30
+
+// Check is the outcome of one preflight check.
+type Check struct {
+	Category string `json:"category"`
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Detail   string `json:"detail"`
+}
+
+// Doctor checks that the given technologies and tests can run here: the
+// commands they use, the load generator, their test data, ports, the report
+// and workspace directories, and signals that make results noisy.
+func (r *Runner) Doctor(techs, tests []string, outputDir string) []Check {
+	var checks []Check
+	checks = append(checks, r.checkCommands(techs, tests)...)
+	checks = append(checks, r.checkDatasets(techs, tests)...)
+	checks = append(checks, r.checkPorts(techs, tests)...)
+	checks = append(checks, checkDirectory("output directory", outputDir, 0))
+	checks = append(checks, checkDirectory("workdir", r.workdir, r.largestFootprint(techs, tests)))
+	checks = append(checks, checkGovernor(), checkLoad(), checkSwap())
+	return checks
+}
+
+// benchmarks calls fn for every selected pair the configuration supports.
+func (r *Runner) benchmarks(techs, tests []string, fn func(tech, test string)) {
+	for _, tech := range techs {
+		for _, test := range tests {
+			if r.config.ValidateBenchmark(tech, test) {
+				fn(tech, test)
+			}
+		}
+	}
+}
+
+func (r *Runner) checkCommands(techs, tests []string) []Check {
+	// Executable -> the technologies and tests that run it
+	users := map[string][]string{}
+	for _, tech := range techs {
+		technology := r.config.Technologies[tech]
+		if len(technology.VersionCommand) > 0 {
+			users[technology.VersionCommand[0]] = append(users[technology.VersionCommand[0]], tech+" version_command")
+		}
+	}
+	needsWrk := []string{}
+	r.benchmarks(techs, tests, func(tech, test string) {
+		benchmark := r.config.Technologies[tech].Benchmarks[test]
+		if len(benchmark.Command) > 0 {
+			users[benchmark.Command[0]] = append(users[benchmark.Command[0]], tech+" - "+test)
+		}
+		if benchmark.Type == "server" {
+			needsWrk = append(needsWrk, tech+" - "+test)
+		}
+	})
+	if len(needsWrk) > 0 {
+		users["wrk"] = needsWrk
+	}
+
+	var checks []Check
+	for _, executable := range sortedKeys(users) {
+		check := Check{Category: "command", Name: executable}
+		if path, err := exec.LookPath(executable); err != nil {
+			check.Status = CheckFail
+			check.Detail = fmt.Sprintf("not found on PATH, needed by %s", summarize(users[executable]))
+		} else {
+			check.Status = CheckPass
+			check.Detail = path
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+func (r *Runner) checkDatasets(techs, tests []string) []Check {
+	names := map[string]bool{}
+	r.benchmarks(techs, tests, func(tech, test string) {
+		benchmark := r.config.Technologies[tech].Benchmarks[test]
+		for _, name := range requiredDatasets(test, benchmark.DefaultParams, nil) {
+			names[name] = true
+		}
+	})
+	if len(names) == 0 {
+		return nil
+	}
+
+	dir := filepath.Join(r.projectRoot, "test_data")
+	manifest, err := data.LoadManifest(dir)
+	if err != nil {
+		return []Check{{Category: "data", Name: data.ManifestFile, Status: CheckFail, Detail: err.Error()}}
+	}
+
+	var checks []Check
+	for _, name := range sortedKeys(names) {
+		check := Check{Category: "data", Name: name, Status: CheckPass, Detail: "matches the manifest"}
+		if _, err := os.Stat(filepath.Join(dir, name)); os.IsNotExist(err) {
+			check.Status = CheckWarn
+			check.Detail = "missing, it will be generated before the first benchmark that reads it"
+		} else if manifest == nil {
+			check.Status = CheckFail
+			check.Detail = fmt.Sprintf("no %s in %s, run 'benchmark-cli data generate'", data.ManifestFile, dir)
+		} else if err := data.Verify(dir, manifest, name); err != nil {
+			check.Status = CheckFail
+			check.Detail = fmt.Sprintf("%v, run 'benchmark-cli data generate'", err)
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+func (r *Runner) checkPorts(techs, tests []string) []Check {
+	users := map[int][]string{}
+	add := func(port int, user string) {
+		if port > 0 {
+			users[port] = append(users[port], user)
+		}
+	}
+	r.benchmarks(techs, tests, func(tech, test string) {
+		benchmark := r.config.Technologies[tech].Benchmarks[test]
+		add(benchmark.Port, tech+" - "+test)
+		if port, ok := benchmark.DefaultParams["port"]; ok {
+			var p int
+			fmt.Sscanf(port, "%d", &p)
+			add(p, tech+" - "+test)
+		}
+		// Fixtures and cold starts default to the server's usual port
+		if (benchmark.Requires != "" || benchmark.Type == "cold_start") && benchmark.Port == 0 {
+			add(3000, tech+" - "+test)
+		}
+	})
+
+	ports := make([]int, 0, len(users))
+	for port := range users {
+		ports = append(ports, port)
+	}
+	sort.Ints(ports)
+
+	var checks []Check
+	for _, port := range ports {
+		check := Check{Category: "port", Name: fmt.Sprintf("%d", port), Status: CheckPass, Detail: "free"}
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+		if err != nil {
+			check.Status = CheckFail
+			check.Detail = fmt.Sprintf("in use (%v), needed by %s", err, summarize(users[port]))
+		} else {
+			listener.Close()
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// largestFootprint is the most any selected writer benchmark is expected to
+// keep in its workspace.
+func (r *Runner) largestFootprint(techs, tests []string) int64 {
+	var largest int64
+	r.benchmarks(techs, tests, func(tech, test string) {
+		benchmark := r.config.Technologies[tech].Benchmarks[test]
+		if _, ok := benchmark.DefaultParams["output"]; !ok {
+			return
+		}
+		if footprint := expectedFootprint(test, benchmark.DefaultParams); footprint > largest {
+			largest = footprint
+		}
+	})
+	return largest
+}
+
+// checkDirectory makes sure dir can be created and written to and has room
+// for need bytes plus the workspace headroom.
+func checkDirectory(name, dir string, need int64) Check {
+	check := Check{Category: "directory", Name: name}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		check.Status = CheckFail
+		check.Detail = fmt.Sprintf("cannot create %s: %v", dir, err)
+		return check
+	}
+	probe, err := os.CreateTemp(dir, ".doctor-")
+	if err != nil {
+		check.Status = CheckFail
+		check.Detail = fmt.Sprintf("%s is not writable: %v", dir, err)
+		return check
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+
+	usage, err := disk.Usage(dir)
+	if err != nil {
+		check.Status = CheckWarn
+		check.Detail = fmt.Sprintf("%s is writable, free space unknown: %v", dir, err)
+		return check
+	}
+
+	check.Status = CheckPass
+	check.Detail = fmt.Sprintf("%s is writable, %s free", dir, formatBytes(usage.Free))
+	if needed := uint64(need) + workspaceHeadroom; need > 0 && usage.Free < needed {
+		check.Status = CheckFail
+		check.Detail = fmt.Sprintf("%s has %s free, benchmarks need %s", dir, formatBytes(usage.Free), formatBytes(needed))
+	} else if usage.Free < lowDiskSpace {
+		check.Status = CheckWarn
+		check.Detail = fmt.Sprintf("%s has only %s free", dir, formatBytes(usage.Free))
+	}
+	return check
+}
+
+// checkGovernor warns when CPU frequency scaling may change clock speeds
+// during a run.
+func checkGovernor() Check {
+	check := Check{Category: "environment", Name: "cpu governor", Status: CheckPass}
+	if runtime.GOOS != "linux" {
+		check.Detail = "not checked on " + runtime.GOOS
+		return check
+	}
+
+	paths, _ := filepath.Glob("/sys/devices/system/cpu/cpu[0-9]*/cpufreq/scaling_governor")
+	if len(paths) == 0 {
+		check.Detail = "no frequency scaling exposed"
+		return check
+	}
+	governors := map[string]int{}
+	for _, path := range paths {
+		if content, err := os.ReadFile(path); err == nil {
+			governors[strings.TrimSpace(string(content))]++
+		}
+	}
+
+	var parts []string
+	for _, governor := range sortedKeys(governors) {
+		parts = append(parts, fmt.Sprintf("%s on %d cpus", governor, governors[governor]))
+	}
+	check.Detail = strings.Join(parts, ", ")
+	if len(governors) != 1 || governors["performance"] == 0 {
+		check.Status = CheckWarn
+		check.Detail += "; use the performance governor for stable clock speeds"
+	}
+	return check
+}
+
+// checkLoad warns when other work is already keeping the CPUs busy.
+func checkLoad() Check {
+	check := Check{Category: "environment", Name: "load average", Status: CheckPass}
+	avg, err := load.Avg()
+	if err != nil {
+		check.Detail = fmt.Sprintf("unknown: %v", err)
+		return check
+	}
+
+	check.Detail = fmt.Sprintf("%.2f over the last minute on %d cpus", avg.Load1, runtime.NumCPU())
+	limit := float64(runtime.NumCPU()) / 10
+	if limit < 1 {
+		limit = 1
+	}
+	if avg.Load1 > limit {
+		check.Status = CheckWarn
+		check.Detail += "; other processes will compete with the benchmarks"
+	}
+	return check
+}
+
+// checkSwap warns when memory has been swapped out, since paging skews
+// memory-heavy benchmarks.
+func checkSwap() Check {
+	check := Check{Category: "environment", Name: "swap", Status: CheckPass}
+	swap, err := mem.SwapMemory()
+	if err != nil {
+		check.Detail = fmt.Sprintf("unknown: %v", err)
+		return check
+	}
+
+	switch {
+	case swap.Total == 0:
+		check.Detail = "disabled"
+	case swap.Used == 0:
+		check.Detail = fmt.Sprintf("%s configured, none in use", formatBytes(swap.Total))
+	default:
+		check.Status = CheckWarn
+		check.Detail = fmt.Sprintf("%s of %s in use; free memory before benchmarking", formatBytes(swap.Used), formatBytes(swap.Total))
+	}
+	return check
+}
+
+// summarize lists up to three users and how many more there are.
+func summarize(users []string) string {
+	if len(users) <= 3 {
+		return strings.Join(users, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(users[:3], ", "), len(users)-3)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatBytes(bytes uint64) string {
+	switch {
+	case bytes >= 1<<30:
+		return fmt.Sprintf("%.1fGB", float64(bytes)/(1<<30))
+	case bytes >= 1<<20:
+		return fmt.Sprintf("%.1fMB", float64(bytes)/(1<<20))
+	default:
+		return fmt.Sprintf("%dKB", bytes>>10)
+	}
+}
@@ -0,0 +1,143 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"performance-benchmark-suite/orchestrator/config"
+	"performance-benchmark-suite/orchestrator/report"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// Executor runs benchmarks of one type. The runner calls Prepare, Start and
+// Measure in turn and stops at the first error; Teardown is called in every
+// case, so it must cope with a benchmark that got only part of the way. Plan
+// is called instead of all four when a run is only planned.
+type Executor interface {
+	// Plan describes on planned the commands, ports, workspace and test data
+	// the benchmark would use, or skips it, without starting or creating
+	// anything.
+	Plan(run *BenchmarkRun, planned *PlannedTrial)
+	// Prepare checks the parameters and sets up what the benchmark needs
+	// before any process starts, such as test data and workspaces.
+	Prepare(run *BenchmarkRun) error
+	// Start launches the processes under test and begins monitoring them.
+	Start(run *BenchmarkRun) error
+	// Measure drives the workload and returns the result.
+	Measure(run *BenchmarkRun) (*report.BenchmarkResult, error)
+	// Teardown stops what Start launched and removes what Prepare created.
+	Teardown(run *BenchmarkRun)
+}
+
+// BenchmarkRun is a benchmark going through an executor's lifecycle. Params
+// are the overrides the benchmark was run with, without its defaults.
+type BenchmarkRun struct {
+	Tech      string
+	Test      string
+	Benchmark *config.Benchmark
+	Params    map[string]string
+
+	runner  *Runner
+	monitor *monitor
+}
+
+// registeredExecutor is how benchmarks of a registered type are run.
+type registeredExecutor struct {
+	benchmarkType config.BenchmarkType
+	newExecutor   func() Executor
+}
+
+var executors = map[string]registeredExecutor{}
+
+// RegisterExecutor runs benchmarks of benchmarkType with an executor from
+// newExecutor, one per benchmark.
+func RegisterExecutor(benchmarkType config.BenchmarkType, newExecutor func() Executor) {
+	executors[benchmarkType.Name] = registeredExecutor{benchmarkType: benchmarkType, newExecutor: newExecutor}
+}
+
+// BenchmarkTypes returns the benchmark types an executor is registered for,
+// which are the types a configuration may use.
+func BenchmarkTypes() []config.BenchmarkType {
+	types := make([]config.BenchmarkType, 0, len(executors))
+	for _, registered := range executors {
+		types = append(types, registered.benchmarkType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].Name < types[j].Name })
+	return types
+}
+
+func init() {
+	RegisterExecutor(config.BenchmarkType{Name: "benchmark"}, func() Executor { return &regularExecutor{} })
+	RegisterExecutor(config.BenchmarkType{Name: "server"}, func() Executor { return &serverExecutor{} })
+	RegisterExecutor(config.BenchmarkType{Name: "websocket"}, func() Executor { return &webSocketExecutor{} })
+	RegisterExecutor(config.BenchmarkType{Name: "grpc"}, func() Executor { return &grpcExecutor{} })
+	RegisterExecutor(config.BenchmarkType{Name: "concurrency_search", Commandless: true}, func() Executor { return &concurrencySearchExecutor{} })
+	RegisterExecutor(config.BenchmarkType{Name: "cold_start", Commandless: true}, func() Executor { return &coldStartExecutor{} })
+}
+
+func (r *Runner) runBenchmark(tech, test string, params map[string]string) (*report.BenchmarkResult, error) {
+	benchmark, err := r.config.GetBenchmark(tech, test)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get benchmark config: %v", err)
+	}
+	registered, ok := executors[benchmark.Type]
+	if !ok {
+		return nil, fmt.Errorf("no executor for benchmark type %q of %s - %s", benchmark.Type, tech, test)
+	}
+	run := &BenchmarkRun{Tech: tech, Test: test, Benchmark: benchmark, Params: params, runner: r}
+	executor := registered.newExecutor()
+	defer func() {
+		executor.Teardown(run)
+		run.monitor.stop()
+	}()
+
+	if err := executor.Prepare(run); err != nil {
+		return nil, err
+	}
+	if err := executor.Start(run); err != nil {
+		return nil, err
+	}
+	return executor.Measure(run)
+}
+
+// Monitor samples the memory and CPU use of proc until the benchmark's
+// metrics are collected.
+func (b *BenchmarkRun) Monitor(proc *process.Process) {
+	b.monitor.stop()
+	b.monitor = b.runner.startMonitor(proc)
+}
+
+// Metrics stops monitoring and returns what was sampled.
+func (b *BenchmarkRun) Metrics() ProcessMetrics {
+	return b.monitor.stop()
+}
+
+// monitor samples a process in the background.
+type monitor struct {
+	cancel  context.CancelFunc
+	metrics chan ProcessMetrics
+	result  *ProcessMetrics
+}
+
+func (r *Runner) startMonitor(proc *process.Process) *monitor {
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &monitor{cancel: cancel, metrics: make(chan ProcessMetrics, 1)}
+	go r.monitorProcess(ctx, proc, m.metrics)
+	return m
+}
+
+// stop ends sampling and returns the metrics. It may be called more than
+// once, and on a nil monitor, which measured nothing.
+func (m *monitor) stop() ProcessMetrics {
+	if m == nil {
+		return ProcessMetrics{}
+	}
+	if m.result == nil {
+		m.cancel()
+		metrics := <-m.metrics
+		m.result = &metrics
+	}
+	return *m.result
+}
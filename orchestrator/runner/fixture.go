@@ -0,0 +1,103 @@
+package runner
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"performance-benchmark-suite/orchestrator/report"
+)
+
+// fixture is a server a client benchmark depends on. The runner starts it
+// through the same lifecycle as server benchmarks and monitors it while the
+// dependent benchmark runs.
+type fixture struct {
+	test    string
+	port    int
+	server  *serverProcess
+	monitor *monitor
+}
+
+// startFixture starts the benchmark named by requires for tech and waits
+// until it is ready.
+func (r *Runner) startFixture(tech, requires string) (*fixture, error) {
+	benchmark, err := r.config.GetBenchmark(tech, requires)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get required benchmark config: %v", err)
+	}
+
+	port := benchmark.Port
+	if port == 0 {
+		port = 3000
+	}
+
+	// HTTP servers bind their own port; servers that take a port are told it
+	args := map[string]string{}
+	switch benchmark.Type {
+	case "server":
+	case "websocket":
+		args["port"] = fmt.Sprintf("%d", port)
+	default:
+		return nil, fmt.Errorf("%s - %s cannot be used as a fixture (type %s)", tech, requires, benchmark.Type)
+	}
+
+	if portOpen(fmt.Sprintf("127.0.0.1:%d", port)) {
+		return nil, fmt.Errorf("port %d is already in use, stop whatever is listening on it first", port)
+	}
+
+	slog.Info("starting fixture", "tech", tech, "fixture", requires)
+	cmd, err := r.buildBenchmarkCommand(tech, requires, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build fixture command: %v", err)
+	}
+
+	server, err := r.startServer(tech, cmd, httpHealthCheck(fmt.Sprintf("http://localhost:%d/health", port)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &fixture{
+		test:    requires,
+		port:    port,
+		server:  server,
+		monitor: r.startMonitor(server.proc),
+	}, nil
+}
+
+// args returns params with the fixture's port added.
+func (f *fixture) args(params map[string]string) map[string]string {
+	args := mergeParams(params, nil)
+	args["port"] = fmt.Sprintf("%d", f.port)
+	return args
+}
+
+// env tells the dependent benchmark where the fixture listens.
+func (f *fixture) env(env []string) []string {
+	if env == nil {
+		env = os.Environ()
+	}
+	return append(env, fmt.Sprintf("BENCHMARK_SERVER_ADDR=localhost:%d", f.port))
+}
+
+// finish stops monitoring the fixture and returns what it used while the
+// dependent benchmark ran. It fails if the fixture died along the way, since
+// the benchmark's numbers would be meaningless.
+func (f *fixture) finish() (*report.Fixture, error) {
+	if !f.server.alive() {
+		return nil, fmt.Errorf("fixture %s exited during the benchmark - stdout: %s, stderr: %s",
+			f.test, f.server.stdout.String(), f.server.stderr.String())
+	}
+
+	metrics := f.monitor.stop()
+	return &report.Fixture{
+		Test:          f.test,
+		MaxMemoryMB:   metrics.MaxMemoryMB,
+		AvgCPUPercent: metrics.AvgCPUPercent,
+	}, nil
+}
+
+// stop tears the fixture down. It is safe to call after finish.
+func (f *fixture) stop() {
+	f.monitor.stop()
+	f.server.stop()
+}
@@ -0,0 +1,37 @@
+package runner
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFixtureArgs(t *testing.T) {
+	f := &fixture{test: "http_server", port: 3000}
+	params := map[string]string{"port": "8080", "duration": "5s"}
+
+	got := f.args(params)
+	want := map[string]string{"port": "3000", "duration": "5s"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if params["port"] != "8080" {
+		t.Errorf("args changed the benchmark's parameters to %v", params)
+	}
+}
+
+func TestFixtureEnv(t *testing.T) {
+	f := &fixture{test: "http_server", port: 3001}
+
+	got := f.env([]string{"TMPDIR=/tmp/bench"})
+	want := []string{"TMPDIR=/tmp/bench", "BENCHMARK_SERVER_ADDR=localhost:3001"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Without an environment of its own the benchmark inherits the runner's
+	t.Setenv("FIXTURE_TEST_MARKER", "1")
+	inherited := f.env(nil)
+	if !contains(inherited, "FIXTURE_TEST_MARKER=1") || inherited[len(inherited)-1] != "BENCHMARK_SERVER_ADDR=localhost:3001" {
+		t.Errorf("env(nil) = %v, want the runner's environment plus the server address", inherited)
+	}
+}
@@ -0,0 +1,345 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"performance-benchmark-suite/orchestrator/report"
+	"performance-benchmark-suite/proto/benchmarkpb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// grpcWorkerStats is what a single client worker observed during the run.
+type grpcWorkerStats struct {
+	calls     int
+	messages  int
+	errors    int
+	latencies []time.Duration
+	backoff   time.Duration
+}
+
+// Workers back off after a failed call, doubling up to the maximum, so a
+// server that refuses calls is not hammered by every worker at full CPU
+const (
+	grpcMinBackoff = 10 * time.Millisecond
+	grpcMaxBackoff = 500 * time.Millisecond
+)
+
+// failed counts a failed call unless the run is over and waits before the
+// next one.
+func (s *grpcWorkerStats) failed(ctx context.Context) {
+	if ctx.Err() != nil {
+		return
+	}
+	s.errors++
+	s.backoff = min(max(2*s.backoff, grpcMinBackoff), grpcMaxBackoff)
+	select {
+	case <-ctx.Done():
+	case <-time.After(s.backoff):
+	}
+}
+
+// succeeded records a completed call and resets the backoff.
+func (s *grpcWorkerStats) succeeded(latency time.Duration) {
+	s.latencies = append(s.latencies, latency)
+	s.calls++
+	s.backoff = 0
+}
+
+// grpcExecutor starts a gRPC server and drives it with the orchestrator's
+// own client workers, spread over a number of client connections.
+type grpcExecutor struct {
+	params         map[string]string
+	mode           string
+	concurrency    int
+	connections    int
+	duration       time.Duration
+	messageSize    int
+	streamMessages int
+	port           int
+	clientConns    []*grpc.ClientConn
+	server         *serverProcess
+}
+
+func (e *grpcExecutor) Prepare(run *BenchmarkRun) error {
+	slog.Info("starting gRPC benchmark", "tech", run.Tech, "test", run.Test)
+	return e.configure(run)
+}
+
+// configure resolves the client parameters and port for both Prepare and Plan.
+func (e *grpcExecutor) configure(run *BenchmarkRun) error {
+	e.params = mergeParams(run.Benchmark.DefaultParams, run.Params)
+
+	e.mode = e.params["mode"]
+	if e.mode == "" {
+		e.mode = "unary"
+	}
+	if e.mode != "unary" && e.mode != "server_stream" && e.mode != "bidi_stream" {
+		return fmt.Errorf("invalid mode parameter: %s (use 'unary', 'server_stream' or 'bidi_stream')", e.mode)
+	}
+	var err error
+	if e.concurrency, err = paramInt(e.params, "concurrency", 50); err != nil {
+		return err
+	}
+	if e.connections, err = paramInt(e.params, "connections", 1); err != nil {
+		return err
+	}
+	if e.duration, err = paramDuration(e.params, "duration", 10*time.Second); err != nil {
+		return err
+	}
+	if e.messageSize, err = paramInt(e.params, "message-size", 64); err != nil {
+		return err
+	}
+	if e.streamMessages, err = paramInt(e.params, "stream-messages", 10); err != nil {
+		return err
+	}
+	if e.concurrency < 1 || e.connections < 1 || e.streamMessages < 1 {
+		return fmt.Errorf("concurrency, connections and stream-messages must be positive")
+	}
+
+	e.port = run.Benchmark.Port
+	if e.port == 0 {
+		e.port = 50051
+	}
+	return nil
+}
+
+func (e *grpcExecutor) Plan(run *BenchmarkRun, planned *PlannedTrial) {
+	if err := e.configure(run); err != nil {
+		planned.skip(SkipInvalidParams, "%v", err)
+		return
+	}
+	run.runner.planCommand(planned, "server", run.Test, map[string]string{"port": fmt.Sprintf("%d", e.port)}, nil)
+	planned.Client = fmt.Sprintf("built-in grpc client, %s calls with concurrency %d against localhost:%d", e.mode, e.concurrency, e.port)
+	planned.Ports = []int{e.port}
+}
+
+// Start opens the client connections first, since the readiness check of
+// the server goes through one of them.
+func (e *grpcExecutor) Start(run *BenchmarkRun) error {
+	// Only the port is passed to the server; the other parameters drive the client
+	serverCmd, err := run.runner.buildBenchmarkCommand(run.Tech, run.Test, map[string]string{"port": fmt.Sprintf("%d", e.port)})
+	if err != nil {
+		return fmt.Errorf("failed to build server command: %v", err)
+	}
+
+	target := fmt.Sprintf("localhost:%d", e.port)
+	for i := 0; i < e.connections; i++ {
+		conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return fmt.Errorf("failed to create gRPC client: %v", err)
+		}
+		e.clientConns = append(e.clientConns, conn)
+	}
+
+	e.server, err = run.runner.startServer(run.Tech, serverCmd, grpcHealthCheck(e.clientConns[0]))
+	if err != nil {
+		return err
+	}
+	run.Monitor(e.server.proc)
+	return nil
+}
+
+func (e *grpcExecutor) Measure(run *BenchmarkRun) (*report.BenchmarkResult, error) {
+	tech, server := run.Tech, e.server
+	slog.Info("running gRPC load", "tech", tech, "mode", e.mode, "concurrency", e.concurrency,
+		"connections", e.connections, "size", e.messageSize, "duration", e.duration.String())
+
+	payload := make([]byte, e.messageSize)
+	for i := range payload {
+		payload[i] = 'x'
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.duration)
+	defer cancel()
+
+	// Stop the load as soon as the server exits instead of counting every
+	// refused call until the duration is up
+	var serverExited atomic.Bool
+	go func() {
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if !server.alive() {
+					serverExited.Store(true)
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	stats := make([]*grpcWorkerStats, e.concurrency)
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < e.concurrency; i++ {
+		stats[i] = &grpcWorkerStats{}
+		client := benchmarkpb.NewBenchmarkServiceClient(e.clientConns[i%e.connections])
+
+		wg.Add(1)
+		go func(s *grpcWorkerStats) {
+			defer wg.Done()
+			switch e.mode {
+			case "unary":
+				runUnaryWorker(ctx, client, payload, s)
+			case "server_stream":
+				runServerStreamWorker(ctx, client, payload, int32(e.streamMessages), s)
+			case "bidi_stream":
+				runBidiStreamWorker(ctx, client, payload, s)
+			}
+		}(stats[i])
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	processMetrics := run.Metrics()
+	if serverExited.Load() {
+		return nil, fmt.Errorf("%s gRPC server exited during the benchmark, server stderr: %s", tech, server.stderr.String())
+	}
+
+	var calls, messages, errors int
+	var latencies []time.Duration
+	for _, s := range stats {
+		calls += s.calls
+		messages += s.messages
+		errors += s.errors
+		latencies = append(latencies, s.latencies...)
+	}
+	if calls == 0 {
+		return nil, fmt.Errorf("no successful gRPC calls (%d errors), server stderr: %s", errors, server.stderr.String())
+	}
+
+	latency := summarizeDurations(latencies)
+	requestsPerSecond := float64(calls) / elapsed.Seconds()
+
+	slog.Info("gRPC benchmark completed", "tech", tech, "calls", calls, "messages", messages,
+		"errors", errors, "rps", requestsPerSecond, "p99Ms", latency.P99Ms)
+
+	return &report.BenchmarkResult{
+		Tech:       tech,
+		Test:       run.Test,
+		Parameters: e.params,
+		Metrics: report.Metrics{
+			RequestsPerSecond: requestsPerSecond,
+			MessagesPerSecond: float64(messages) / elapsed.Seconds(),
+			LatencyAvgMs:      latency.AvgMs,
+			LatencyP50Ms:      latency.P50Ms,
+			LatencyP75Ms:      latency.P75Ms,
+			LatencyP90Ms:      latency.P90Ms,
+			LatencyP95Ms:      latency.P95Ms,
+			LatencyP99Ms:      latency.P99Ms,
+			Errors:            errors,
+			MaxMemoryMB:       processMetrics.MaxMemoryMB,
+			AvgCPUPercent:     processMetrics.AvgCPUPercent,
+		},
+	}, nil
+}
+
+func (e *grpcExecutor) Teardown(run *BenchmarkRun) {
+	for _, conn := range e.clientConns {
+		conn.Close()
+	}
+	if e.server != nil {
+		e.server.stop()
+	}
+}
+
+// grpcHealthCheck returns a readiness check using the standard gRPC health service.
+func grpcHealthCheck(conn *grpc.ClientConn) func() error {
+	client := healthpb.NewHealthClient(conn)
+	return func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+		defer cancel()
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+		if err != nil {
+			return err
+		}
+		if resp.Status != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("health check returned %s", resp.Status)
+		}
+		return nil
+	}
+}
+
+// runUnaryWorker issues back-to-back unary calls and records each call's latency.
+func runUnaryWorker(ctx context.Context, client benchmarkpb.BenchmarkServiceClient, payload []byte, s *grpcWorkerStats) {
+	req := &benchmarkpb.EchoRequest{Payload: payload}
+	for ctx.Err() == nil {
+		callStart := time.Now()
+		_, err := client.Unary(ctx, req)
+		if err != nil {
+			s.failed(ctx)
+			continue
+		}
+		s.succeeded(time.Since(callStart))
+		s.messages++
+	}
+}
+
+// runServerStreamWorker opens one stream per call and records the time until
+// the last message arrives.
+func runServerStreamWorker(ctx context.Context, client benchmarkpb.BenchmarkServiceClient, payload []byte, count int32, s *grpcWorkerStats) {
+	req := &benchmarkpb.StreamRequest{Payload: payload, Count: count}
+	for ctx.Err() == nil {
+		callStart := time.Now()
+		stream, err := client.ServerStream(ctx, req)
+		if err != nil {
+			s.failed(ctx)
+			continue
+		}
+
+		received := 0
+		for {
+			_, err = stream.Recv()
+			if err != nil {
+				break
+			}
+			received++
+		}
+		s.messages += received
+		if err != io.EOF {
+			s.failed(ctx)
+			continue
+		}
+		s.succeeded(time.Since(callStart))
+	}
+}
+
+// runBidiStreamWorker keeps a single stream open and records the round trip
+// of every message sent on it.
+func runBidiStreamWorker(ctx context.Context, client benchmarkpb.BenchmarkServiceClient, payload []byte, s *grpcWorkerStats) {
+	req := &benchmarkpb.EchoRequest{Payload: payload}
+	for ctx.Err() == nil {
+		stream, err := client.BidiStream(ctx)
+		if err != nil {
+			s.failed(ctx)
+			continue
+		}
+
+		for ctx.Err() == nil {
+			callStart := time.Now()
+			if err = stream.Send(req); err == nil {
+				_, err = stream.Recv()
+			}
+			if err != nil {
+				s.failed(ctx)
+				break
+			}
+			s.succeeded(time.Since(callStart))
+			s.messages++
+		}
+		stream.CloseSend()
+	}
+}
@@ -0,0 +1,107 @@
+package runner
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"performance-benchmark-suite/orchestrator/config"
+)
+
+func TestGRPCConfigure(t *testing.T) {
+	tests := []struct {
+		name      string
+		benchmark config.Benchmark
+		params    map[string]string
+		want      grpcExecutor
+		wantErr   bool
+	}{
+		{
+			name: "defaults",
+			want: grpcExecutor{mode: "unary", concurrency: 50, connections: 1, duration: 10 * time.Second, messageSize: 64, streamMessages: 10, port: 50051},
+		},
+		{
+			name:      "overrides win over the defaults",
+			benchmark: config.Benchmark{Port: 50052, DefaultParams: map[string]string{"mode": "server_stream", "concurrency": "20"}},
+			params:    map[string]string{"concurrency": "4", "connections": "2", "stream-messages": "100"},
+			want:      grpcExecutor{mode: "server_stream", concurrency: 4, connections: 2, duration: 10 * time.Second, messageSize: 64, streamMessages: 100, port: 50052},
+		},
+		{name: "unknown mode", params: map[string]string{"mode": "client_stream"}, wantErr: true},
+		{name: "no concurrency", params: map[string]string{"concurrency": "0"}, wantErr: true},
+		{name: "no connections", params: map[string]string{"connections": "0"}, wantErr: true},
+		{name: "no stream messages", params: map[string]string{"stream-messages": "0"}, wantErr: true},
+		{name: "invalid message size", params: map[string]string{"message-size": "large"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &grpcExecutor{}
+			err := e.configure(&BenchmarkRun{Tech: "go", Test: "grpc_server", Benchmark: &tt.benchmark, Params: tt.params})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("configure: %v", err)
+			}
+			// The merged parameters are covered by mergeParams
+			e.params = nil
+			if !reflect.DeepEqual(*e, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", *e, tt.want)
+			}
+		})
+	}
+}
+
+func TestGRPCWorkerBackoff(t *testing.T) {
+	ctx := context.Background()
+	s := &grpcWorkerStats{}
+
+	want := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond}
+	for i, backoff := range want {
+		s.failed(ctx)
+		if s.backoff != backoff {
+			t.Errorf("after %d failures backoff is %v, want %v", i+1, s.backoff, backoff)
+		}
+	}
+	if s.errors != len(want) {
+		t.Errorf("counted %d errors, want %d", s.errors, len(want))
+	}
+
+	s.backoff = grpcMaxBackoff
+	s.succeeded(time.Millisecond)
+	if s.backoff != 0 || s.calls != 1 || len(s.latencies) != 1 {
+		t.Errorf("after a success got backoff %v, %d calls and %d latencies, want 0, 1 and 1", s.backoff, s.calls, len(s.latencies))
+	}
+
+	s.backoff = grpcMaxBackoff
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	started := time.Now()
+	s.failed(canceled)
+	if s.errors != len(want) {
+		t.Errorf("a call failing after the run ended was counted")
+	}
+	if elapsed := time.Since(started); elapsed >= grpcMaxBackoff {
+		t.Errorf("failing after the run ended waited %v", elapsed)
+	}
+}
+
+func TestGRPCWorkerBackoffLimit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := &grpcWorkerStats{backoff: grpcMaxBackoff}
+
+	// The wait is cut short by the end of the run, but the backoff stays capped
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	s.failed(ctx)
+	if s.backoff != grpcMaxBackoff {
+		t.Errorf("backoff is %v, want it capped at %v", s.backoff, grpcMaxBackoff)
+	}
+}
@@ -0,0 +1,102 @@
+package runner
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// InterruptedError is returned by RunBenchmark when the orchestrator was
+// interrupted while the benchmark ran. The benchmark has been torn down.
+type InterruptedError struct {
+	Signal os.Signal
+}
+
+func (e *InterruptedError) Error() string {
+	return fmt.Sprintf("interrupted by %v", e.Signal)
+}
+
+// ExitCode is the status a shell reports for a process ended by the signal.
+func (e *InterruptedError) ExitCode() int {
+	if sig, ok := e.Signal.(syscall.Signal); ok {
+		return 128 + int(sig)
+	}
+	return 130
+}
+
+// interrupt tracks the process groups the runner started, so they can be
+// killed when the orchestrator is interrupted. Killing them makes the
+// executor waiting on them return, after which the benchmark is torn down as
+// usual.
+type interrupt struct {
+	mu     sync.Mutex
+	signal os.Signal
+	groups map[*exec.Cmd]bool
+}
+
+// started records cmd, which was started in its own process group. It is
+// killed straight away if the orchestrator was already interrupted.
+func (i *interrupt) started(cmd *exec.Cmd) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.signal != nil {
+		killProcessGroup(cmd)
+	}
+	if i.groups == nil {
+		i.groups = map[*exec.Cmd]bool{}
+	}
+	i.groups[cmd] = true
+}
+
+// stopped forgets cmd once it has been reaped, since its process group ID
+// may be reused.
+func (i *interrupt) stopped(cmd *exec.Cmd) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.groups, cmd)
+}
+
+func (i *interrupt) interrupt(sig os.Signal) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.signal == nil {
+		i.signal = sig
+	}
+	for cmd := range i.groups {
+		killProcessGroup(cmd)
+	}
+}
+
+// NotifyInterrupt makes SIGINT and SIGTERM end the run instead of the
+// orchestrator: the processes of the benchmark in progress are killed and
+// RunBenchmark returns an *InterruptedError once it is torn down. The
+// returned function restores the default handling.
+func (r *Runner) NotifyInterrupt() (stop func()) {
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				r.interrupt.interrupt(sig)
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
+
+// Interrupted returns the signal the orchestrator was interrupted by, or nil.
+func (r *Runner) Interrupted() os.Signal {
+	r.interrupt.mu.Lock()
+	defer r.interrupt.mu.Unlock()
+	return r.interrupt.signal
+}
@@ -0,0 +1,74 @@
+package runner
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestInterruptedErrorExitCode(t *testing.T) {
+	tests := []struct {
+		signal os.Signal
+		want   int
+	}{
+		{signal: os.Interrupt, want: 130},
+		{signal: syscall.SIGTERM, want: 143},
+	}
+
+	for _, tt := range tests {
+		if got := (&InterruptedError{Signal: tt.signal}).ExitCode(); got != tt.want {
+			t.Errorf("%v: exit code %d, want %d", tt.signal, got, tt.want)
+		}
+	}
+}
+
+func TestInterruptKillsStartedProcesses(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sleep")
+	}
+
+	start := func() *exec.Cmd {
+		cmd := exec.Command("sleep", "30")
+		setProcessGroup(cmd)
+		if err := cmd.Start(); err != nil {
+			t.Fatal(err)
+		}
+		return cmd
+	}
+	wait := func(cmd *exec.Cmd) {
+		done := make(chan struct{})
+		go func() {
+			cmd.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			cmd.Process.Kill()
+			t.Errorf("process %d was not killed", cmd.Process.Pid)
+		}
+	}
+
+	r := &Runner{}
+	running := start()
+	r.interrupt.started(running)
+	r.interrupt.interrupt(syscall.SIGTERM)
+	wait(running)
+	r.interrupt.stopped(running)
+
+	// Processes started after the interrupt are killed straight away
+	late := start()
+	r.interrupt.started(late)
+	wait(late)
+	r.interrupt.stopped(late)
+
+	if sig := r.Interrupted(); sig != syscall.SIGTERM {
+		t.Errorf("Interrupted() = %v, want %v", sig, syscall.SIGTERM)
+	}
+	if len(r.interrupt.groups) != 0 {
+		t.Errorf("%d process groups still tracked", len(r.interrupt.groups))
+	}
+}
@@ -0,0 +1,288 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"performance-benchmark-suite/orchestrator/report"
+)
+
+// levelResult is what one closed-loop client did during a measured level.
+type levelResult struct {
+	latencies []time.Duration
+	errors    int
+}
+
+// concurrencySearchExecutor finds the highest number of concurrent clients
+// the required server sustains within a latency and error-rate SLO. It
+// doubles the client count until the SLO is broken, then binary searches
+// between the last passing and first failing level. Every measured level is
+// kept as the throughput/latency curve.
+type concurrencySearchExecutor struct {
+	params       map[string]string
+	startClients int
+	maxClients   int
+	resolution   int
+	duration     time.Duration
+	warmup       time.Duration
+	slo          report.SLO
+	path         string
+	requires     string
+	fx           *fixture
+}
+
+func (e *concurrencySearchExecutor) Prepare(run *BenchmarkRun) error {
+	slog.Info("starting concurrency search", "tech", run.Tech, "test", run.Test)
+	return e.configure(run)
+}
+
+// configure resolves the search parameters for both Prepare and Plan.
+func (e *concurrencySearchExecutor) configure(run *BenchmarkRun) error {
+	e.params = mergeParams(run.Benchmark.DefaultParams, run.Params)
+
+	var err error
+	if e.startClients, err = paramInt(e.params, "start-clients", 8); err != nil {
+		return err
+	}
+	if e.maxClients, err = paramInt(e.params, "max-clients", 512); err != nil {
+		return err
+	}
+	if e.resolution, err = paramInt(e.params, "resolution", 8); err != nil {
+		return err
+	}
+	if e.duration, err = paramDuration(e.params, "duration", 5*time.Second); err != nil {
+		return err
+	}
+	if e.warmup, err = paramDuration(e.params, "warmup", time.Second); err != nil {
+		return err
+	}
+	if e.slo.P99Ms, err = paramFloat(e.params, "p99-slo-ms", 50); err != nil {
+		return err
+	}
+	if e.slo.ErrorRate, err = paramFloat(e.params, "error-rate-slo", 0.01); err != nil {
+		return err
+	}
+	if e.startClients < 1 || e.maxClients < e.startClients || e.resolution < 1 {
+		return fmt.Errorf("start-clients must be positive, max-clients at least start-clients and resolution positive")
+	}
+	e.path = e.params["path"]
+	if e.path == "" {
+		e.path = "/"
+	}
+
+	e.requires = run.Benchmark.Requires
+	if e.requires == "" {
+		e.requires = "http_server"
+	}
+	return nil
+}
+
+func (e *concurrencySearchExecutor) Plan(run *BenchmarkRun, planned *PlannedTrial) {
+	if err := e.configure(run); err != nil {
+		planned.skip(SkipInvalidParams, "%v", err)
+		return
+	}
+	port := run.runner.planFixture(planned, e.requires)
+	planned.Client = fmt.Sprintf("built-in concurrency search from %d to %d clients against http://localhost:%d%s", e.startClients, e.maxClients, port, e.path)
+}
+
+// Start starts the server under test as a fixture, which monitors it.
+func (e *concurrencySearchExecutor) Start(run *BenchmarkRun) error {
+	fx, err := run.runner.startFixture(run.Tech, e.requires)
+	if err != nil {
+		return fmt.Errorf("failed to start fixture: %v", err)
+	}
+	e.fx = fx
+	return nil
+}
+
+func (e *concurrencySearchExecutor) Measure(run *BenchmarkRun) (*report.BenchmarkResult, error) {
+	tech := run.Tech
+	url := fmt.Sprintf("http://localhost:%d%s", e.fx.port, e.path)
+	slog.Info("searching for the concurrency knee", "tech", tech, "url", url, "startClients", e.startClients,
+		"maxClients", e.maxClients, "p99SloMs", e.slo.P99Ms, "errorRateSlo", e.slo.ErrorRate)
+
+	measured := map[int]report.ConcurrencyLevel{}
+	measure := func(clients int) report.ConcurrencyLevel {
+		if level, ok := measured[clients]; ok {
+			return level
+		}
+		level := measureConcurrencyLevel(url, clients, e.warmup, e.duration, e.slo)
+		status := "ok"
+		if !level.WithinSLO {
+			status = "violates SLO: " + level.Violation
+		}
+		slog.Debug("concurrency level", "tech", tech, "clients", clients, "rps", level.RequestsPerSecond,
+			"p99Ms", level.LatencyP99Ms, "errorRate", level.ErrorRate, "status", status)
+		measured[clients] = level
+		return level
+	}
+
+	// Exponential phase: double until the SLO breaks or max-clients is reached
+	knee, failing := 0, 0
+	for clients := e.startClients; ; clients *= 2 {
+		if clients > e.maxClients {
+			clients = e.maxClients
+		}
+		if !measure(clients).WithinSLO {
+			failing = clients
+			break
+		}
+		knee = clients
+		if clients == e.maxClients {
+			break
+		}
+	}
+
+	// Binary phase: narrow the gap between the last passing and first failing level
+	if failing > 0 && knee > 0 {
+		for failing-knee > e.resolution {
+			mid := (knee + failing) / 2
+			if measure(mid).WithinSLO {
+				knee = mid
+			} else {
+				failing = mid
+			}
+		}
+	}
+
+	serverMetrics, err := e.fx.finish()
+	if err != nil {
+		return nil, err
+	}
+
+	curve := make([]report.ConcurrencyLevel, 0, len(measured))
+	for _, level := range measured {
+		curve = append(curve, level)
+	}
+	sort.Slice(curve, func(i, j int) bool { return curve[i].Clients < curve[j].Clients })
+
+	result := &report.BenchmarkResult{
+		Tech:       tech,
+		Test:       run.Test,
+		Parameters: e.params,
+		Metrics: report.Metrics{
+			MaxConcurrentClients: knee,
+			MaxMemoryMB:          serverMetrics.MaxMemoryMB,
+			AvgCPUPercent:        serverMetrics.AvgCPUPercent,
+		},
+		Fixture: serverMetrics,
+		ConcurrencySearch: &report.ConcurrencySearch{
+			SLO:   e.slo,
+			Knee:  knee,
+			Curve: curve,
+		},
+	}
+	for _, level := range curve {
+		if level.WithinSLO && level.RequestsPerSecond > result.Metrics.MaxRequestsPerSecond {
+			result.Metrics.MaxRequestsPerSecond = level.RequestsPerSecond
+		}
+	}
+	if level, ok := measured[knee]; ok {
+		result.Metrics.RequestsPerSecond = level.RequestsPerSecond
+		result.Metrics.LatencyAvgMs = level.LatencyAvgMs
+		result.Metrics.LatencyP50Ms = level.LatencyP50Ms
+		result.Metrics.LatencyP90Ms = level.LatencyP90Ms
+		result.Metrics.LatencyP99Ms = level.LatencyP99Ms
+	}
+
+	if knee == 0 {
+		slog.Warn("SLO violated at the starting concurrency", "tech", tech, "clients", e.startClients)
+	} else {
+		slog.Info("concurrency knee found", "tech", tech, "clients", knee)
+	}
+	return result, nil
+}
+
+func (e *concurrencySearchExecutor) Teardown(run *BenchmarkRun) {
+	if e.fx != nil {
+		e.fx.stop()
+	}
+}
+
+// measureConcurrencyLevel runs clients closed-loop HTTP clients against url,
+// discarding the warmup period, and checks the result against the SLO.
+func measureConcurrencyLevel(url string, clients int, warmup, duration time.Duration, slo report.SLO) report.ConcurrencyLevel {
+	transport := &http.Transport{
+		MaxIdleConns:        clients,
+		MaxIdleConnsPerHost: clients,
+	}
+	defer transport.CloseIdleConnections()
+	client := &http.Client{Transport: transport, Timeout: 5 * time.Second}
+
+	measureFrom := time.Now().Add(warmup)
+	deadline := measureFrom.Add(duration)
+
+	results := make([]levelResult, clients)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(res *levelResult) {
+			defer wg.Done()
+			for {
+				start := time.Now()
+				if !start.Before(deadline) {
+					return
+				}
+				failed := false
+				resp, err := client.Get(url)
+				if err != nil {
+					failed = true
+				} else {
+					io.Copy(io.Discard, resp.Body)
+					resp.Body.Close()
+					failed = resp.StatusCode >= 400
+				}
+				if start.Before(measureFrom) {
+					continue
+				}
+				res.latencies = append(res.latencies, time.Since(start))
+				if failed {
+					res.errors++
+				}
+			}
+		}(&results[i])
+	}
+	wg.Wait()
+
+	var latencies []time.Duration
+	errors := 0
+	for _, res := range results {
+		latencies = append(latencies, res.latencies...)
+		errors += res.errors
+	}
+
+	summary := summarizeDurations(latencies)
+	level := report.ConcurrencyLevel{
+		Clients:           clients,
+		Requests:          len(latencies),
+		Errors:            errors,
+		RequestsPerSecond: float64(len(latencies)) / duration.Seconds(),
+		LatencyAvgMs:      summary.AvgMs,
+		LatencyP50Ms:      summary.P50Ms,
+		LatencyP90Ms:      summary.P90Ms,
+		LatencyP99Ms:      summary.P99Ms,
+		WithinSLO:         true,
+	}
+	if len(latencies) > 0 {
+		level.ErrorRate = float64(errors) / float64(len(latencies))
+	}
+
+	switch {
+	case len(latencies) == 0:
+		level.WithinSLO = false
+		level.Violation = "no requests completed"
+	case level.LatencyP99Ms >= slo.P99Ms:
+		level.WithinSLO = false
+		level.Violation = fmt.Sprintf("p99 %.2fms >= %.2fms", level.LatencyP99Ms, slo.P99Ms)
+	case level.ErrorRate >= slo.ErrorRate && errors > 0:
+		level.WithinSLO = false
+		level.Violation = fmt.Sprintf("error rate %.2f%% >= %.2f%%", level.ErrorRate*100, slo.ErrorRate*100)
+	}
+	return level
+}
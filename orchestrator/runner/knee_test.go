@@ -0,0 +1,110 @@
+package runner
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"performance-benchmark-suite/orchestrator/config"
+	"performance-benchmark-suite/orchestrator/report"
+)
+
+func TestConcurrencySearchConfigure(t *testing.T) {
+	tests := []struct {
+		name      string
+		benchmark config.Benchmark
+		params    map[string]string
+		want      concurrencySearchExecutor
+		wantErr   bool
+	}{
+		{
+			name: "defaults",
+			want: concurrencySearchExecutor{
+				startClients: 8, maxClients: 512, resolution: 8, duration: 5 * time.Second, warmup: time.Second,
+				slo: report.SLO{P99Ms: 50, ErrorRate: 0.01}, path: "/", requires: "http_server",
+			},
+		},
+		{
+			name:      "overrides win over the defaults",
+			benchmark: config.Benchmark{Requires: "json_server", DefaultParams: map[string]string{"max-clients": "64", "path": "/json"}},
+			params:    map[string]string{"start-clients": "2", "p99-slo-ms": "10"},
+			want: concurrencySearchExecutor{
+				startClients: 2, maxClients: 64, resolution: 8, duration: 5 * time.Second, warmup: time.Second,
+				slo: report.SLO{P99Ms: 10, ErrorRate: 0.01}, path: "/json", requires: "json_server",
+			},
+		},
+		{name: "no start clients", params: map[string]string{"start-clients": "0"}, wantErr: true},
+		{name: "max below start", params: map[string]string{"start-clients": "16", "max-clients": "8"}, wantErr: true},
+		{name: "no resolution", params: map[string]string{"resolution": "0"}, wantErr: true},
+		{name: "invalid slo", params: map[string]string{"p99-slo-ms": "fast"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &concurrencySearchExecutor{}
+			err := e.configure(&BenchmarkRun{Tech: "go", Test: "concurrency_limit", Benchmark: &tt.benchmark, Params: tt.params})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("configure: %v", err)
+			}
+			// The merged parameters are covered by mergeParams
+			e.params = nil
+			if !reflect.DeepEqual(*e, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", *e, tt.want)
+			}
+		})
+	}
+}
+
+func TestMeasureConcurrencyLevel(t *testing.T) {
+	slo := report.SLO{P99Ms: 1000, ErrorRate: 0.01}
+	tests := []struct {
+		name      string
+		handler   http.HandlerFunc
+		slo       report.SLO
+		withinSLO bool
+		violation string
+	}{
+		{
+			name:      "fast server",
+			handler:   func(w http.ResponseWriter, r *http.Request) {},
+			slo:       slo,
+			withinSLO: true,
+		},
+		{
+			name:      "failing server",
+			handler:   func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusInternalServerError) },
+			slo:       slo,
+			violation: "error rate",
+		},
+		{
+			name:      "slow server",
+			handler:   func(w http.ResponseWriter, r *http.Request) { time.Sleep(20 * time.Millisecond) },
+			slo:       report.SLO{P99Ms: 5, ErrorRate: 0.01},
+			violation: "p99",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			level := measureConcurrencyLevel(server.URL, 2, 0, 200*time.Millisecond, tt.slo)
+			if level.Clients != 2 || level.Requests == 0 {
+				t.Fatalf("measured %d requests with %d clients, want some with 2", level.Requests, level.Clients)
+			}
+			if level.WithinSLO != tt.withinSLO || !strings.HasPrefix(level.Violation, tt.violation) {
+				t.Errorf("within SLO %v with violation %q, want %v with a %q violation", level.WithinSLO, level.Violation, tt.withinSLO, tt.violation)
+			}
+		})
+	}
+}
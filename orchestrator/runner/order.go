@@ -0,0 +1,90 @@
+package runner
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// Execution orders for a list of trials
+const (
+	OrderSequential = "sequential"
+	OrderInterleave = "interleave"
+	OrderRandom     = "random"
+)
+
+var Orders = []string{OrderSequential, OrderInterleave, OrderRandom}
+
+// Trial is one execution of a benchmark: a technology and test with the
+// parameters to run it with, the sweep combination they include and which
+// of the repeated runs it is, counting from 1.
+type Trial struct {
+	Tech   string
+	Test   string
+	Params map[string]string
+	Sweep  map[string]string
+	Run    int
+}
+
+// OrderTrials arranges trials, given technology by technology with each
+// test's runs back to back, in the requested order. Interleaving takes one
+// trial of every technology in turn for each test and parameter set, one run
+// at a time and starting with a different technology each run, so drift over
+// the session affects all technologies alike. Random shuffles all trials with
+// the given seed.
+func OrderTrials(trials []Trial, order string, seed int64) ([]Trial, error) {
+	ordered := append([]Trial(nil), trials...)
+
+	switch order {
+	case OrderSequential:
+	case OrderInterleave:
+		groups := make(map[string]int)
+		techs := make(map[string]int)
+		for _, trial := range ordered {
+			if _, ok := groups[trial.group()]; !ok {
+				groups[trial.group()] = len(groups)
+			}
+			if _, ok := techs[trial.Tech]; !ok {
+				techs[trial.Tech] = len(techs)
+			}
+		}
+		// Each run starts with the next technology, so none always goes first
+		turn := func(t Trial) int {
+			return (techs[t.Tech] - (t.Run - 1) + len(techs)*t.Run) % len(techs)
+		}
+		sort.SliceStable(ordered, func(i, j int) bool {
+			a, b := ordered[i], ordered[j]
+			if a.Run != b.Run {
+				return a.Run < b.Run
+			}
+			if groups[a.group()] != groups[b.group()] {
+				return groups[a.group()] < groups[b.group()]
+			}
+			return turn(a) < turn(b)
+		})
+	case OrderRandom:
+		rng := rand.New(rand.NewSource(seed))
+		rng.Shuffle(len(ordered), func(i, j int) {
+			ordered[i], ordered[j] = ordered[j], ordered[i]
+		})
+	default:
+		return nil, fmt.Errorf("invalid order: %s (use %s)", order, strings.Join(Orders, ", "))
+	}
+	return ordered, nil
+}
+
+// group identifies trials of the same test and sweep combination.
+func (t Trial) group() string {
+	keys := make([]string, 0, len(t.Sweep))
+	for key := range t.Sweep {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+t.Sweep[key])
+	}
+	return t.Test + "?" + strings.Join(pairs, "&")
+}
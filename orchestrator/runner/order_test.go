@@ -0,0 +1,110 @@
+package runner
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// trials builds the sequential order for techs, tests and runs: technology
+// by technology, with each test's runs back to back.
+func trials(techs, tests []string, runs int) []Trial {
+	var list []Trial
+	for _, tech := range techs {
+		for _, test := range tests {
+			for run := 1; run <= runs; run++ {
+				list = append(list, Trial{Tech: tech, Test: test, Run: run})
+			}
+		}
+	}
+	return list
+}
+
+func labels(list []Trial) []string {
+	out := make([]string, len(list))
+	for i, trial := range list {
+		out[i] = fmt.Sprintf("%s/%s#%d", trial.Tech, trial.Test, trial.Run)
+	}
+	return out
+}
+
+func TestOrderTrialsInterleave(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []Trial
+		want  []string
+	}{
+		{
+			name:  "first technology rotates each run",
+			input: trials([]string{"go", "node", "bun"}, []string{"a"}, 3),
+			want: []string{
+				"go/a#1", "node/a#1", "bun/a#1",
+				"node/a#2", "bun/a#2", "go/a#2",
+				"bun/a#3", "go/a#3", "node/a#3",
+			},
+		},
+		{
+			name:  "every test of a run before the next run",
+			input: trials([]string{"go", "node"}, []string{"a", "b"}, 2),
+			want: []string{
+				"go/a#1", "node/a#1", "go/b#1", "node/b#1",
+				"node/a#2", "go/a#2", "node/b#2", "go/b#2",
+			},
+		},
+		{
+			name: "sweep combinations are separate groups",
+			input: []Trial{
+				{Tech: "go", Test: "a", Run: 1, Sweep: map[string]string{"size": "1"}},
+				{Tech: "go", Test: "a", Run: 1, Sweep: map[string]string{"size": "2"}},
+				{Tech: "node", Test: "a", Run: 1, Sweep: map[string]string{"size": "1"}},
+				{Tech: "node", Test: "a", Run: 1, Sweep: map[string]string{"size": "2"}},
+			},
+			want: []string{"go/a#1", "node/a#1", "go/a#1", "node/a#1"},
+		},
+		{
+			name:  "single technology keeps its order",
+			input: trials([]string{"go"}, []string{"a", "b"}, 2),
+			want:  []string{"go/a#1", "go/b#1", "go/a#2", "go/b#2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ordered, err := OrderTrials(tt.input, OrderInterleave, 0)
+			if err != nil {
+				t.Fatalf("OrderTrials: %v", err)
+			}
+			if got := labels(ordered); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %v\nwant %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderTrials(t *testing.T) {
+	input := trials([]string{"go", "node", "bun"}, []string{"a", "b"}, 3)
+
+	sequential, err := OrderTrials(input, OrderSequential, 0)
+	if err != nil {
+		t.Fatalf("sequential: %v", err)
+	}
+	if !reflect.DeepEqual(labels(sequential), labels(input)) {
+		t.Errorf("sequential changed the order: %v", labels(sequential))
+	}
+
+	first, err := OrderTrials(input, OrderRandom, 42)
+	if err != nil {
+		t.Fatalf("random: %v", err)
+	}
+	second, _ := OrderTrials(input, OrderRandom, 42)
+	if !reflect.DeepEqual(labels(first), labels(second)) {
+		t.Errorf("the same seed gave different orders:\n%v\n%v", labels(first), labels(second))
+	}
+	if len(first) != len(input) {
+		t.Errorf("random returned %d trials, want %d", len(first), len(input))
+	}
+
+	if _, err := OrderTrials(input, "alphabetical", 0); err == nil {
+		t.Error("expected an error for an unknown order")
+	}
+}
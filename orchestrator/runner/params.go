@@ -0,0 +1,60 @@
+package runner
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// mergeParams returns the benchmark's default parameters overlaid with the
+// parameters given on the command line.
+func mergeParams(defaults, overrides map[string]string) map[string]string {
+	merged := make(map[string]string, len(defaults)+len(overrides))
+	for key, value := range defaults {
+		merged[key] = value
+	}
+	for key, value := range overrides {
+		merged[key] = value
+	}
+	return merged
+}
+
+func paramInt(params map[string]string, key string, def int) (int, error) {
+	value, ok := params[key]
+	if !ok || value == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s parameter: %s", key, value)
+	}
+	return n, nil
+}
+
+func paramFloat(params map[string]string, key string, def float64) (float64, error) {
+	value, ok := params[key]
+	if !ok || value == "" {
+		return def, nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s parameter: %s", key, value)
+	}
+	return f, nil
+}
+
+// paramDuration accepts Go durations ("15s") as well as bare seconds ("15").
+func paramDuration(params map[string]string, key string, def time.Duration) (time.Duration, error) {
+	value, ok := params[key]
+	if !ok || value == "" {
+		return def, nil
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s parameter: %s", key, value)
+	}
+	return d, nil
+}
@@ -0,0 +1,209 @@
+package runner
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Reasons a planned trial is skipped instead of run
+const (
+	SkipUnsupported      = "unsupported"
+	SkipMissingToolchain = "missing toolchain"
+	SkipMissingData      = "missing data"
+	SkipInvalidParams    = "invalid parameters"
+)
+
+// plannedBuildDir stands for the temporary directory binaries are built into,
+// which only exists once the trial runs
+const plannedBuildDir = "<tmp>/"
+
+// PlannedTrial is how a trial will be executed, resolved from the
+// configuration without running anything. Skip is set, with SkipReason
+// saying why, when the trial cannot run here.
+type PlannedTrial struct {
+	Tech          string            `json:"tech"`
+	Test          string            `json:"test"`
+	Run           int               `json:"run,omitempty"`
+	Sweep         map[string]string `json:"sweep,omitempty"`
+	Type          string            `json:"type,omitempty"`
+	DefaultParams map[string]string `json:"defaultParams,omitempty"`
+	Overrides     map[string]string `json:"overrides,omitempty"`
+	Ports         []int             `json:"ports,omitempty"`
+	Workspace     string            `json:"workspace,omitempty"`
+	Datasets      []PlannedDataset  `json:"datasets,omitempty"`
+	Commands      []PlannedCommand  `json:"commands,omitempty"`
+	Client        string            `json:"client,omitempty"`
+	Notes         []string          `json:"notes,omitempty"`
+	Skip          string            `json:"skip,omitempty"`
+	SkipReason    string            `json:"skipReason,omitempty"`
+}
+
+// PlannedCommand is a process the trial starts. Env holds only what is added
+// to the orchestrator's environment.
+type PlannedCommand struct {
+	Role string   `json:"role"`
+	Args []string `json:"args"`
+	Dir  string   `json:"dir"`
+	Env  []string `json:"env,omitempty"`
+}
+
+// PlannedDataset is a test_data file the trial reads. Missing files are
+// generated before the benchmark starts.
+type PlannedDataset struct {
+	Path    string `json:"path"`
+	Present bool   `json:"present"`
+}
+
+func (p *PlannedTrial) skip(reason, format string, args ...interface{}) {
+	if p.Skip == "" {
+		p.Skip, p.SkipReason = reason, fmt.Sprintf(format, args...)
+	}
+}
+
+// Plan resolves how trial would be executed: its parameters, ports,
+// workspace, test data, command lines and environment, or why it would be
+// skipped.
+func (r *Runner) Plan(trial Trial) PlannedTrial {
+	planned := PlannedTrial{
+		Tech:      trial.Tech,
+		Test:      trial.Test,
+		Run:       trial.Run,
+		Sweep:     trial.Sweep,
+		Overrides: trial.Params,
+	}
+
+	benchmark, err := r.config.GetBenchmark(trial.Tech, trial.Test)
+	if err != nil {
+		planned.skip(SkipUnsupported, "%v", err)
+		return planned
+	}
+	planned.Type = benchmark.Type
+	planned.DefaultParams = benchmark.DefaultParams
+	registered, ok := executors[benchmark.Type]
+	if !ok {
+		planned.skip(SkipUnsupported, "no executor for benchmark type %q", benchmark.Type)
+		return planned
+	}
+	run := &BenchmarkRun{Tech: trial.Tech, Test: trial.Test, Benchmark: benchmark, Params: trial.Params, runner: r}
+	registered.newExecutor().Plan(run, &planned)
+
+	for _, command := range planned.Commands {
+		if strings.HasPrefix(command.Args[0], plannedBuildDir) {
+			continue
+		}
+		if _, err := exec.LookPath(command.Args[0]); err != nil {
+			planned.skip(SkipMissingToolchain, "%s not found on PATH", command.Args[0])
+		}
+	}
+	for _, port := range planned.Ports {
+		if portOpen(fmt.Sprintf("127.0.0.1:%d", port)) {
+			planned.Notes = append(planned.Notes, fmt.Sprintf("port %d is in use now", port))
+		}
+	}
+	return planned
+}
+
+// planRegular resolves a benchmark the runner starts and reads results from:
+// its test data, workspace and the fixture it depends on.
+func (r *Runner) planRegular(planned *PlannedTrial, params map[string]string) {
+	benchmark, _ := r.config.GetBenchmark(planned.Tech, planned.Test)
+
+	dataDir := filepath.Join(r.projectRoot, "test_data")
+	for _, name := range requiredDatasets(planned.Test, benchmark.DefaultParams, params) {
+		_, err := os.Stat(filepath.Join(dataDir, name))
+		planned.Datasets = append(planned.Datasets, PlannedDataset{Path: filepath.Join("test_data", name), Present: err == nil})
+		if err != nil {
+			planned.Notes = append(planned.Notes, fmt.Sprintf("test_data/%s is generated before the run", name))
+		}
+	}
+	if file := mergeParams(benchmark.DefaultParams, params)["file"]; file != "" && len(planned.Datasets) == 0 {
+		path := file
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(r.projectRoot, path)
+		}
+		if _, err := os.Stat(path); err != nil {
+			planned.skip(SkipMissingData, "%s not found and not a generated dataset", file)
+		}
+	}
+
+	args := params
+	var env []string
+	if defaultOutput, ok := benchmark.DefaultParams["output"]; ok && params["output"] == "" {
+		planned.Workspace = filepath.Join(r.workdir, fmt.Sprintf("bench-%s-%s-*", planned.Tech, planned.Test))
+		args = mergeParams(args, map[string]string{"output": filepath.Join(planned.Workspace, filepath.Base(defaultOutput))})
+		env = append(env, "TMPDIR="+planned.Workspace, "BENCHMARK_WORKDIR="+planned.Workspace)
+	}
+	if benchmark.Requires != "" {
+		port := r.planFixture(planned, benchmark.Requires)
+		args = mergeParams(args, map[string]string{"port": strconv.Itoa(port)})
+		env = append(env, fmt.Sprintf("BENCHMARK_SERVER_ADDR=localhost:%d", port))
+	}
+	if buildArgs, runArgs, ok := splitGoRun(benchmark.Command); ok {
+		binary := plannedBuildDir + planned.Test + exeSuffix()
+		buildCmd := append([]string{"go", "build", "-o", binary}, buildArgs...)
+		planned.Commands = append(planned.Commands, PlannedCommand{Role: "build", Args: buildCmd, Dir: r.projectRoot})
+		r.planCmd(planned, "benchmark", planned.Test, r.command(append([]string{binary}, runArgs...), args), env)
+		return
+	}
+	r.planCommand(planned, "benchmark", planned.Test, args, env)
+}
+
+// planFixture adds the server a benchmark depends on and returns its port.
+func (r *Runner) planFixture(planned *PlannedTrial, requires string) int {
+	benchmark, err := r.config.GetBenchmark(planned.Tech, requires)
+	if err != nil {
+		planned.skip(SkipUnsupported, "fixture %s: %v", requires, err)
+		return 0
+	}
+
+	port := benchmark.Port
+	if port == 0 {
+		port = 3000
+	}
+	args := map[string]string{}
+	switch benchmark.Type {
+	case "server":
+	case "websocket":
+		args["port"] = strconv.Itoa(port)
+	default:
+		planned.skip(SkipUnsupported, "%s cannot be used as a fixture (type %s)", requires, benchmark.Type)
+		return port
+	}
+	r.planCommand(planned, "fixture", requires, args, nil)
+	planned.Ports = append(planned.Ports, port)
+	return port
+}
+
+// planCommand adds the command of test, with params as arguments and the
+// profiling flags the run would add.
+func (r *Runner) planCommand(planned *PlannedTrial, role, test string, params map[string]string, env []string) {
+	cmd, err := r.benchmarkCommand(planned.Tech, test, params)
+	if err != nil {
+		planned.skip(SkipUnsupported, "%v", err)
+		return
+	}
+	r.planCmd(planned, role, test, cmd, env)
+}
+
+// planCmd adds cmd with the profiling flags the run would add.
+func (r *Runner) planCmd(planned *PlannedTrial, role, test string, cmd *exec.Cmd, env []string) {
+	args := cmd.Args
+	if r.profiling != nil {
+		trial := Trial{Tech: planned.Tech, Test: planned.Test, Run: planned.Run, Sweep: planned.Sweep}
+		profileArgs, profileEnv, _, unsupported, err := r.profileFlags(trial, test)
+		if err != nil {
+			planned.skip(SkipUnsupported, "%v", err)
+			return
+		}
+		for _, kind := range unsupported {
+			planned.Notes = append(planned.Notes, fmt.Sprintf("%s does not support %s profiling", planned.Tech, kind))
+		}
+		args = append(args[:1], append(profileArgs, args[1:]...)...)
+		env = append(env, profileEnv...)
+	}
+	planned.Commands = append(planned.Commands, PlannedCommand{Role: role, Args: args, Dir: cmd.Dir, Env: env})
+}
@@ -5,14 +5,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
+	"io"
+	"log/slog"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
-	"syscall"
+	"sync"
 	"time"
 
 	"performance-benchmark-suite/orchestrator/config"
@@ -23,7 +25,10 @@ import (
 
 type Runner struct {
 	projectRoot string
+	workdir     string
+	profiling   *profiling
 	config      *config.Config
+	interrupt   interrupt
 }
 
 type ProcessMetrics struct {
@@ -32,207 +37,147 @@ type ProcessMetrics struct {
 	SampleCount   int
 }
 
-func NewRunner() (*Runner, error) {
-	// Get the project root - try to find it relative to current directory
-	var projectRoot string
-
-	// First, try to find the config file relative to current directory
-	if _, err := os.Stat("config/technologies.yaml"); err == nil {
-		projectRoot, _ = filepath.Abs(".")
-	} else if _, err := os.Stat("../config/technologies.yaml"); err == nil {
-		projectRoot, _ = filepath.Abs("..")
-	} else if _, err := os.Stat("../../config/technologies.yaml"); err == nil {
-		projectRoot, _ = filepath.Abs("../..")
-	} else {
-		// Fallback: assume we're in the orchestrator directory
-		projectRoot, _ = filepath.Abs(filepath.Join("..", ".."))
-	}
-
-	// Load configuration
-	cfg, err := config.LoadConfig("")
-	if err != nil {
-		return nil, fmt.Errorf("failed to load configuration: %v", err)
+// NewRunner creates a runner for cfg. Benchmark commands run in the project
+// root the configuration was loaded for.
+func NewRunner(cfg *config.Config) (*Runner, error) {
+	info, err := os.Stat(cfg.Root)
+	if err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("project root %s is not a directory", cfg.Root)
 	}
 
 	return &Runner{
-		projectRoot: projectRoot,
+		projectRoot: cfg.Root,
+		workdir:     os.TempDir(),
 		config:      cfg,
 	}, nil
 }
 
-func (r *Runner) RunBenchmark(tech, test string, params map[string]string) (*report.BenchmarkResult, error) {
-	// Get benchmark configuration
-	benchmark, err := r.config.GetBenchmark(tech, test)
+// SetWorkdir sets the directory benchmark workspaces are created in.
+func (r *Runner) SetWorkdir(dir string) error {
+	abs, err := filepath.Abs(dir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get benchmark config: %v", err)
+		return fmt.Errorf("invalid workdir %s: %v", dir, err)
 	}
+	r.workdir = abs
+	return nil
+}
 
-	// Handle server tests specially
-	if benchmark.Type == "server" {
-		return r.runServerBenchmark(tech, test, params)
+// RunBenchmark runs a trial. When profiling, its profiles are kept apart
+// from those of every other run and sweep combination.
+func (r *Runner) RunBenchmark(trial Trial) (*report.BenchmarkResult, error) {
+	if r.profiling != nil {
+		r.profiling.trial = trial
 	}
-
-	// Handle regular benchmark tests
-	return r.runRegularBenchmark(tech, test, params)
+	result, err := r.runBenchmark(trial.Tech, trial.Test, trial.Params)
+	if sig := r.Interrupted(); sig != nil {
+		return nil, &InterruptedError{Signal: sig}
+	}
+	if err != nil || r.profiling == nil {
+		return result, err
+	}
+	result.Profiles = r.collectProfiles(trial.Tech, trial.Test)
+	return result, nil
 }
 
-func (r *Runner) runServerBenchmark(tech, test string, params map[string]string) (*report.BenchmarkResult, error) {
-	fmt.Printf("\n=== Starting HTTP Server Benchmark: %s ===\n", tech)
+// serverExecutor benchmarks an HTTP server with wrk, monitoring the server
+// while the load runs.
+type serverExecutor struct {
+	duration    string
+	connections string
+	threads     int
+	raised      bool
+	server      *serverProcess
+}
 
-	// Build server command
-	serverCmd, err := r.buildBenchmarkCommand(tech, test, params)
-	if err != nil {
-		return nil, fmt.Errorf("failed to build server command: %v", err)
+func (e *serverExecutor) Prepare(run *BenchmarkRun) error {
+	slog.Info("starting HTTP server benchmark", "tech", run.Tech, "test", run.Test)
+	if err := e.configure(run); err != nil {
+		return err
 	}
-
-	// Capture server stdout and stderr for debugging
-	serverStdout, err := serverCmd.StdoutPipe()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get server stdout pipe: %v", err)
+	if e.raised {
+		slog.Info("raised connections to the wrk thread count", "requested", run.Params["connections"], "connections", e.connections)
 	}
+	return nil
+}
 
-	serverStderr, err := serverCmd.StderrPipe()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get server stderr pipe: %v", err)
+// configure resolves the load parameters for both Prepare and Plan.
+func (e *serverExecutor) configure(run *BenchmarkRun) error {
+	e.duration = run.Params["duration"]
+	if e.duration == "" {
+		e.duration = "15s"
 	}
-
-	// Start capturing server logs
-	var serverStdoutData strings.Builder
-	var serverStderrData strings.Builder
-
-	go func() {
-		scanner := bufio.NewScanner(serverStdout)
-		for scanner.Scan() {
-			line := scanner.Text()
-			fmt.Printf("[%s-server-stdout] %s\n", tech, line)
-			serverStdoutData.WriteString(line + "\n")
-		}
-	}()
-
-	go func() {
-		scanner := bufio.NewScanner(serverStderr)
-		for scanner.Scan() {
-			line := scanner.Text()
-			fmt.Printf("[%s-server-stderr] %s\n", tech, line)
-			serverStderrData.WriteString(line + "\n")
-		}
-	}()
-
-	// Start the server
-	fmt.Printf("Starting %s HTTP server...\n", tech)
-	if err := serverCmd.Start(); err != nil {
-		return nil, fmt.Errorf("failed to start %s server: %v", tech, err)
+	e.connections = run.Params["connections"]
+	if e.connections == "" {
+		e.connections = "100"
 	}
 
-	// Setup process monitoring for the server
-	proc, err := process.NewProcess(int32(serverCmd.Process.Pid))
+	// Parse connections as integer to ensure it's at least as large as threads
+	connectionsInt, err := strconv.Atoi(e.connections)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get server process: %v", err)
+		return fmt.Errorf("invalid connections parameter: %s", e.connections)
 	}
 
-	// Start monitoring in a goroutine
-	metricsChan := make(chan ProcessMetrics, 1)
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	go r.monitorProcess(ctx, proc, metricsChan)
-
-	// Ensure server cleanup
-	defer func() {
-		if serverCmd.Process != nil {
-			fmt.Printf("Stopping %s server...\n", tech)
-			serverCmd.Process.Kill()
-			serverCmd.Wait() // Clean up zombie process
-		}
-	}()
-
-	// Health check with retries
-	maxRetries := 30 // 15 seconds total
-	fmt.Printf("Waiting for %s server to be ready (health check)...\n", tech)
-
-	var healthCheckSuccess bool
-	for i := 0; i < maxRetries; i++ {
-		time.Sleep(500 * time.Millisecond)
-
-		// Check if server process is still running
-		if serverCmd.Process != nil {
-			if proc, err := os.FindProcess(serverCmd.Process.Pid); err == nil {
-				// Try to signal the process to check if it's alive
-				if err := proc.Signal(syscall.Signal(0)); err != nil {
-					return nil, fmt.Errorf("server process died during startup - stdout: %s, stderr: %s",
-						serverStdoutData.String(), serverStderrData.String())
-				}
-			}
-		}
-
-		// Try health check
-		client := &http.Client{Timeout: 1 * time.Second}
-		resp, err := client.Get("http://localhost:3000/health")
-		if err == nil {
-			resp.Body.Close()
-			if resp.StatusCode == 200 {
-				fmt.Printf("%s server is ready!\n", tech)
-				healthCheckSuccess = true
-				break
-			}
-		}
-	}
-
-	if !healthCheckSuccess {
-		return nil, fmt.Errorf("server health check failed after %d retries - stdout: %s, stderr: %s",
-			maxRetries, serverStdoutData.String(), serverStderrData.String())
+	// Ensure connections >= threads (wrk requirement)
+	e.threads = runtime.NumCPU()
+	if connectionsInt < e.threads {
+		e.connections = strconv.Itoa(e.threads)
+		e.raised = true
 	}
+	return nil
+}
 
-	// Build and run wrk command
-	duration := params["duration"]
-	if duration == "" {
-		duration = "15s"
+func (e *serverExecutor) Plan(run *BenchmarkRun, planned *PlannedTrial) {
+	if err := e.configure(run); err != nil {
+		planned.skip(SkipInvalidParams, "%v", err)
+		return
 	}
-	connections := params["connections"]
-	if connections == "" {
-		connections = "100"
+	run.runner.planCommand(planned, "server", run.Test, run.Params, nil)
+	if e.raised {
+		planned.Notes = append(planned.Notes, fmt.Sprintf("connections raised to %s to match wrk's %d threads", e.connections, e.threads))
 	}
+	planned.Commands = append(planned.Commands, PlannedCommand{Role: "load", Args: e.wrkArgs(), Dir: run.runner.projectRoot})
+	planned.Ports = []int{3000}
+}
 
-	// Parse connections as integer to ensure it's at least as large as threads
-	connectionsInt, err := strconv.Atoi(connections)
+// wrkArgs is the wrk command line that loads the server.
+func (e *serverExecutor) wrkArgs() []string {
+	return []string{"wrk", "-t", strconv.Itoa(e.threads), "-c", e.connections, "-d", e.duration, "--latency", "http://localhost:3000"}
+}
+
+func (e *serverExecutor) Start(run *BenchmarkRun) error {
+	serverCmd, err := run.runner.buildBenchmarkCommand(run.Tech, run.Test, run.Params)
 	if err != nil {
-		return nil, fmt.Errorf("invalid connections parameter: %s", connections)
+		return fmt.Errorf("failed to build server command: %v", err)
 	}
 
-	// Ensure connections >= threads (wrk requirement)
-	threads := runtime.NumCPU()
-	if connectionsInt < threads {
-		connectionsInt = threads
-		connections = strconv.Itoa(connectionsInt)
-		fmt.Printf("Adjusted connections from %s to %s to meet wrk requirement (connections >= threads)\n", params["connections"], connections)
+	e.server, err = run.runner.startServer(run.Tech, serverCmd, httpHealthCheck("http://localhost:3000/health"))
+	if err != nil {
+		return err
 	}
+	run.Monitor(e.server.proc)
+	return nil
+}
 
-	fmt.Printf("Running load test against %s server (duration: %s, connections: %s, threads: %d)...\n", tech, duration, connections, threads)
-	wrkCmd := exec.Command("wrk",
-		"-t", strconv.Itoa(threads),
-		"-c", connections,
-		"-d", duration,
-		"--latency",
-		"http://localhost:3000")
+func (e *serverExecutor) Measure(run *BenchmarkRun) (*report.BenchmarkResult, error) {
+	slog.Info("running load test", "tech", run.Tech, "duration", e.duration, "connections", e.connections, "threads", e.threads)
+	args := e.wrkArgs()
+	wrkCmd := exec.Command(args[0], args[1:]...)
 
-	wrkCmd.Dir = r.projectRoot
+	wrkCmd.Dir = run.runner.projectRoot
 
 	// Run wrk and capture output
 	wrkOutput, err := wrkCmd.CombinedOutput()
 	if err != nil {
 		return nil, fmt.Errorf("wrk failed: %v, output: %s, server stdout: %s, server stderr: %s",
-			err, string(wrkOutput), serverStdoutData.String(), serverStderrData.String())
+			err, string(wrkOutput), e.server.stdout.String(), e.server.stderr.String())
 	}
 
-	fmt.Printf("Load test completed for %s\n", tech)
-	fmt.Printf("wrk output:\n%s\n", string(wrkOutput))
-
-	// Stop monitoring and get final metrics
-	cancel()
-	processMetrics := <-metricsChan
+	slog.Info("load test completed", "tech", run.Tech)
+	slog.Debug("wrk output", "tech", run.Tech, "output", string(wrkOutput))
+	processMetrics := run.Metrics()
 
 	// Parse wrk output to extract HTTP metrics
-	result, err := r.parseWrkOutput(tech, test, params, string(wrkOutput))
+	result, err := run.runner.parseWrkOutput(run.Tech, run.Test, run.Params, string(wrkOutput))
 	if err != nil {
 		return nil, err
 	}
@@ -244,67 +189,165 @@ func (r *Runner) runServerBenchmark(tech, test string, params map[string]string)
 	return result, nil
 }
 
-func (r *Runner) runRegularBenchmark(tech, test string, params map[string]string) (*report.BenchmarkResult, error) {
-	cmd, err := r.buildBenchmarkCommand(tech, test, params)
-	if err != nil {
-		return nil, fmt.Errorf("failed to build command: %v", err)
+func (e *serverExecutor) Teardown(run *BenchmarkRun) {
+	if e.server != nil {
+		e.server.stop()
 	}
+}
 
-	// Capture stdout and stderr BEFORE starting the process
-	stdout, err := cmd.StdoutPipe()
+// regularExecutor runs a benchmark program to completion, monitoring it, and
+// reads its metrics from the last JSON line it prints. Client benchmarks get
+// the server they talk to started as a fixture.
+type regularExecutor struct {
+	ws        *workspace
+	fx        *fixture
+	command   []string
+	buildDir  string
+	buildTime time.Duration
+	cmd       *exec.Cmd
+	startTime time.Time
+	stdout    io.Reader
+	stderr    io.Reader
+}
+
+func (e *regularExecutor) Plan(run *BenchmarkRun, planned *PlannedTrial) {
+	run.runner.planRegular(planned, run.Params)
+}
+
+func (e *regularExecutor) Prepare(run *BenchmarkRun) error {
+	if err := run.runner.ensureTestData(run.Tech, run.Test, run.Params); err != nil {
+		return err
+	}
+
+	ws, err := run.runner.prepareWorkspace(run.Tech, run.Test, run.Params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get stdout pipe: %v", err)
+		return err
 	}
+	e.ws = ws
 
-	stderr, err := cmd.StderrPipe()
+	// The resource usage of `go run` would include the go tool compiling and
+	// linking the benchmark, so it is built once and its binary run instead
+	if buildArgs, runArgs, ok := splitGoRun(run.Benchmark.Command); ok {
+		if e.buildDir, err = os.MkdirTemp("", "benchmark-build-"); err != nil {
+			return fmt.Errorf("failed to create build directory: %v", err)
+		}
+		binary := filepath.Join(e.buildDir, run.Test+exeSuffix())
+		started := time.Now()
+		if err := run.runner.goBuild(binary, buildArgs); err != nil {
+			return err
+		}
+		e.buildTime = time.Since(started)
+		e.command = append([]string{binary}, runArgs...)
+	}
+	return nil
+}
+
+func (e *regularExecutor) Start(run *BenchmarkRun) error {
+	r := run.runner
+	args := run.Params
+	if e.ws != nil {
+		args = e.ws.args(args)
+	}
+
+	if run.Benchmark.Requires != "" {
+		fx, err := r.startFixture(run.Tech, run.Benchmark.Requires)
+		if err != nil {
+			return fmt.Errorf("failed to start fixture: %v", err)
+		}
+		e.fx = fx
+		args = fx.args(args)
+	}
+
+	var cmd *exec.Cmd
+	var err error
+	if e.command != nil {
+		cmd, err = r.profiled(r.command(e.command, args), run.Tech, run.Test)
+	} else {
+		cmd, err = r.buildBenchmarkCommand(run.Tech, run.Test, args)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get stderr pipe: %v", err)
+		return fmt.Errorf("failed to build command: %v", err)
+	}
+	if e.ws != nil {
+		cmd.Env = e.ws.env(cmd.Env)
+	}
+	if e.fx != nil {
+		cmd.Env = e.fx.env(cmd.Env)
+	}
+
+	// Capture stdout and stderr BEFORE starting the process
+	if e.stdout, err = cmd.StdoutPipe(); err != nil {
+		return fmt.Errorf("failed to get stdout pipe: %v", err)
+	}
+	if e.stderr, err = cmd.StderrPipe(); err != nil {
+		return fmt.Errorf("failed to get stderr pipe: %v", err)
 	}
 
-	// Start the process
+	// Start the process in its own group, so that what it spawns, such as the
+	// binary `go run` builds, is killed along with it
+	setProcessGroup(cmd)
+	slog.Info("starting benchmark", "tech", run.Tech, "test", run.Test, "command", strings.Join(cmd.Args, " "))
+	e.startTime = time.Now()
 	if err := cmd.Start(); err != nil {
-		return nil, fmt.Errorf("failed to start process: %v", err)
+		return fmt.Errorf("failed to start process: %v", err)
 	}
+	e.cmd = cmd
+	r.interrupt.started(cmd)
 
 	// Get process for monitoring
 	proc, err := process.NewProcess(int32(cmd.Process.Pid))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get process: %v", err)
+		return fmt.Errorf("failed to get process: %v", err)
 	}
+	run.Monitor(proc)
+	return nil
+}
 
-	// Start monitoring in a goroutine
-	metricsChan := make(chan ProcessMetrics, 1)
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	go r.monitorProcess(ctx, proc, metricsChan)
+func (e *regularExecutor) Measure(run *BenchmarkRun) (*report.BenchmarkResult, error) {
+	cmd := e.cmd
 
-	// Read stdout in a goroutine
+	// Read stdout and stderr in goroutines; both must be drained before
+	// Wait closes the pipes or the final JSON line can be lost
+	var readers sync.WaitGroup
+	readers.Add(2)
 	var stdoutData strings.Builder
 	go func() {
-		scanner := bufio.NewScanner(stdout)
+		defer readers.Done()
+		scanner := bufio.NewScanner(e.stdout)
 		for scanner.Scan() {
+			slog.Debug("benchmark output", "tech", run.Tech, "stream", "stdout", "line", scanner.Text())
 			stdoutData.WriteString(scanner.Text() + "\n")
 		}
 	}()
 
-	// Read stderr in a goroutine
 	var stderrData strings.Builder
 	go func() {
-		scanner := bufio.NewScanner(stderr)
+		defer readers.Done()
+		scanner := bufio.NewScanner(e.stderr)
 		for scanner.Scan() {
+			slog.Debug("benchmark output", "tech", run.Tech, "stream", "stderr", "line", scanner.Text())
 			stderrData.WriteString(scanner.Text() + "\n")
 		}
 	}()
+	readers.Wait()
 
 	// Wait for the process to complete
 	if err := cmd.Wait(); err != nil {
 		return nil, fmt.Errorf("process failed: %v, stderr: %s", err, stderrData.String())
 	}
+	usage := resourceUsage(cmd.ProcessState, time.Since(e.startTime))
 
 	// Stop monitoring and get final metrics
-	cancel()
-	metrics := <-metricsChan
+	metrics := run.Metrics()
+
+	// Sampling misses benchmarks shorter than its interval; the exact totals
+	// from wait4 replace the sampled values wherever they are available
+	if usage != nil {
+		metrics.MaxMemoryMB = usage.MaxRSSMB
+		if usage.WallTimeMs > 0 {
+			metrics.AvgCPUPercent = (usage.UserCPUMs + usage.SystemCPUMs) / usage.WallTimeMs * 100
+		}
+	}
 
 	// Parse the JSON output
 	var benchmarkMetrics map[string]interface{}
@@ -323,13 +366,15 @@ func (r *Runner) runRegularBenchmark(tech, test string, params map[string]string
 
 	// Build the result
 	result := &report.BenchmarkResult{
-		Tech:       tech,
-		Test:       test,
-		Parameters: params,
+		Tech:       run.Tech,
+		Test:       run.Test,
+		Parameters: run.Params,
 		Metrics: report.Metrics{
+			BuildTimeMs:   durationMs(e.buildTime),
 			MaxMemoryMB:   metrics.MaxMemoryMB,
 			AvgCPUPercent: metrics.AvgCPUPercent,
 		},
+		Resources: usage,
 	}
 
 	// Add benchmark-specific metrics
@@ -346,14 +391,73 @@ func (r *Runner) runRegularBenchmark(tech, test string, params map[string]string
 		if latency, ok := benchmarkMetrics["latencyAvgMs"].(float64); ok {
 			result.Metrics.LatencyAvgMs = latency
 		}
+		if latency, ok := benchmarkMetrics["latencyP50Ms"].(float64); ok {
+			result.Metrics.LatencyP50Ms = latency
+		}
+		if latency, ok := benchmarkMetrics["latencyP90Ms"].(float64); ok {
+			result.Metrics.LatencyP90Ms = latency
+		}
+		if latency, ok := benchmarkMetrics["latencyP95Ms"].(float64); ok {
+			result.Metrics.LatencyP95Ms = latency
+		}
+		if latency, ok := benchmarkMetrics["latencyP99Ms"].(float64); ok {
+			result.Metrics.LatencyP99Ms = latency
+		}
+		if iops, ok := benchmarkMetrics["iops"].(float64); ok {
+			result.Metrics.IOPS = iops
+		}
+		if throughput, ok := benchmarkMetrics["mbPerSecond"].(float64); ok {
+			result.Metrics.MBPerSecond = throughput
+		}
 		if coldStart, ok := benchmarkMetrics["coldStartTimeMs"].(float64); ok {
 			result.Metrics.ColdStartTimeMs = coldStart
 		}
+		if digest, ok := benchmarkMetrics["digest"].(string); ok {
+			result.Digest = digest
+		}
+		// File benchmarks report the I/O mode they ran with
+		if direct, ok := benchmarkMetrics["direct"].(bool); ok {
+			mode := &report.IOMode{Direct: direct}
+			mode.Sync, _ = benchmarkMetrics["sync"].(string)
+			mode.Cache, _ = benchmarkMetrics["cache"].(string)
+			result.IOMode = mode
+		}
+	}
+	if e.ws != nil {
+		result.Workspace = e.ws.info()
+	}
+	if e.fx != nil {
+		var err error
+		if result.Fixture, err = e.fx.finish(); err != nil {
+			return nil, err
+		}
 	}
 
 	return result, nil
 }
 
+// Teardown kills the benchmark's process group, which is still running when
+// measuring it failed and may hold processes the benchmark left behind, then
+// stops the fixture and removes the workspace.
+func (e *regularExecutor) Teardown(run *BenchmarkRun) {
+	if e.cmd != nil {
+		killProcessGroup(e.cmd)
+		if e.cmd.ProcessState == nil {
+			e.cmd.Wait()
+		}
+		run.runner.interrupt.stopped(e.cmd)
+	}
+	if e.fx != nil {
+		e.fx.stop()
+	}
+	if e.ws != nil {
+		e.ws.remove()
+	}
+	if e.buildDir != "" {
+		os.RemoveAll(e.buildDir)
+	}
+}
+
 func (r *Runner) parseWrkOutput(tech, test string, params map[string]string, output string) (*report.BenchmarkResult, error) {
 	// Parse wrk output to extract RPS, latency average, and percentiles
 	lines := strings.Split(output, "\n")
@@ -361,8 +465,6 @@ func (r *Runner) parseWrkOutput(tech, test string, params map[string]string, out
 	var latencyMs float64
 	var latencyP50Ms, latencyP75Ms, latencyP90Ms, latencyP99Ms float64
 
-	fmt.Printf("Parsing wrk output for %s:\n", tech)
-
 	inLatencyDistribution := false
 
 	for _, line := range lines {
@@ -373,29 +475,29 @@ func (r *Runner) parseWrkOutput(tech, test string, params map[string]string, out
 
 		// Parse requests per second
 		if strings.Contains(line, "Requests/sec:") {
-			fmt.Printf("Found RPS line: %s\n", line)
+			slog.Debug("wrk requests line", "line", line)
 			parts := strings.Fields(line)
 			if len(parts) >= 2 {
 				if rps, err := strconv.ParseFloat(parts[1], 64); err == nil {
 					requestsPerSecond = rps
-					fmt.Printf("Parsed RPS: %f\n", rps)
+					slog.Debug("parsed wrk requests per second", "rps", rps)
 				} else {
-					fmt.Printf("Failed to parse RPS from: %s, error: %v\n", parts[1], err)
+					slog.Warn("failed to parse wrk requests per second", "value", parts[1], "error", err)
 				}
 			}
 		}
 
 		// Parse average latency from Thread Stats section
 		if strings.Contains(line, "Latency") && strings.Contains(line, "us") && !strings.Contains(line, "Distribution") && !inLatencyDistribution {
-			fmt.Printf("Found Latency line: %s\n", line)
+			slog.Debug("wrk latency line", "line", line)
 			parts := strings.Fields(line)
 			if len(parts) >= 2 {
 				latencyStr := strings.TrimSuffix(parts[1], "us")
 				if lat, err := strconv.ParseFloat(latencyStr, 64); err == nil {
 					latencyMs = lat / 1000.0 // Convert microseconds to milliseconds
-					fmt.Printf("Parsed Average Latency: %f ms\n", latencyMs)
+					slog.Debug("parsed wrk average latency", "ms", latencyMs)
 				} else {
-					fmt.Printf("Failed to parse average latency from: %s, error: %v\n", latencyStr, err)
+					slog.Warn("failed to parse wrk average latency", "value", latencyStr, "error", err)
 				}
 			}
 		}
@@ -403,7 +505,7 @@ func (r *Runner) parseWrkOutput(tech, test string, params map[string]string, out
 		// Detect start of Latency Distribution section
 		if strings.Contains(line, "Latency Distribution") {
 			inLatencyDistribution = true
-			fmt.Printf("Found Latency Distribution section\n")
+			slog.Debug("wrk latency distribution")
 			continue
 		}
 
@@ -411,7 +513,7 @@ func (r *Runner) parseWrkOutput(tech, test string, params map[string]string, out
 		if inLatencyDistribution {
 			// Look for lines like "     50%   31.00us" or "     99%  708.00us"
 			if strings.Contains(line, "%") && (strings.Contains(line, "us") || strings.Contains(line, "ms")) {
-				fmt.Printf("Parsing percentile line: %s\n", line)
+				slog.Debug("wrk percentile line", "line", line)
 				parts := strings.Fields(line)
 				if len(parts) >= 2 {
 					percentileStr := strings.TrimSuffix(parts[0], "%")
@@ -432,23 +534,20 @@ func (r *Runner) parseWrkOutput(tech, test string, params map[string]string, out
 					}
 
 					if err == nil {
+						slog.Debug("parsed wrk percentile", "percentile", percentileStr, "ms", latencyValue)
 						switch percentileStr {
 						case "50":
 							latencyP50Ms = latencyValue
-							fmt.Printf("Parsed P50 Latency: %f ms\n", latencyValue)
 						case "75":
 							latencyP75Ms = latencyValue
-							fmt.Printf("Parsed P75 Latency: %f ms\n", latencyValue)
 						case "90":
 							latencyP90Ms = latencyValue
-							fmt.Printf("Parsed P90 Latency: %f ms\n", latencyValue)
 
 						case "99":
 							latencyP99Ms = latencyValue
-							fmt.Printf("Parsed P99 Latency: %f ms\n", latencyValue)
 						}
 					} else {
-						fmt.Printf("Failed to parse percentile value: %s, error: %v\n", valueStr, err)
+						slog.Warn("failed to parse wrk percentile", "percentile", percentileStr, "value", valueStr, "error", err)
 					}
 				}
 			} else if !strings.HasPrefix(line, " ") {
@@ -458,8 +557,8 @@ func (r *Runner) parseWrkOutput(tech, test string, params map[string]string, out
 		}
 	}
 
-	fmt.Printf("Final parsed metrics - RPS: %f, Avg Latency: %f ms, P50: %f ms, P75: %f ms, P90: %f ms, P99: %f ms\n",
-		requestsPerSecond, latencyMs, latencyP50Ms, latencyP75Ms, latencyP90Ms, latencyP99Ms)
+	slog.Info("parsed wrk output", "tech", tech, "rps", requestsPerSecond, "latencyMs", latencyMs,
+		"p50Ms", latencyP50Ms, "p75Ms", latencyP75Ms, "p90Ms", latencyP90Ms, "p99Ms", latencyP99Ms)
 
 	return &report.BenchmarkResult{
 		Tech:       tech,
@@ -477,23 +576,54 @@ func (r *Runner) parseWrkOutput(tech, test string, params map[string]string, out
 }
 
 func (r *Runner) buildBenchmarkCommand(tech, test string, params map[string]string) (*exec.Cmd, error) {
+	cmd, err := r.benchmarkCommand(tech, test, params)
+	if err != nil {
+		return nil, err
+	}
+	return r.profiled(cmd, tech, test)
+}
+
+// profiled adds the profiling flags to cmd when profiling is on.
+func (r *Runner) profiled(cmd *exec.Cmd, tech, test string) (*exec.Cmd, error) {
+	if r.profiling != nil {
+		if err := r.applyProfiling(cmd, tech, test); err != nil {
+			return nil, err
+		}
+	}
+	return cmd, nil
+}
+
+// benchmarkCommand builds the configured command with params as arguments.
+func (r *Runner) benchmarkCommand(tech, test string, params map[string]string) (*exec.Cmd, error) {
 	benchmark, err := r.config.GetBenchmark(tech, test)
 	if err != nil {
 		return nil, err
 	}
 
-	// Build command from configuration
-	cmd := exec.Command(benchmark.Command[0], benchmark.Command[1:]...)
+	if len(benchmark.Command) == 0 {
+		return nil, fmt.Errorf("%s - %s has no command configured", tech, test)
+	}
+	return r.command(benchmark.Command, params), nil
+}
+
+// command builds command with params as arguments, run in the project root.
+func (r *Runner) command(command []string, params map[string]string) *exec.Cmd {
+	cmd := exec.Command(command[0], command[1:]...)
 
-	// Add parameters as command line arguments
-	for key, value := range params {
-		cmd.Args = append(cmd.Args, fmt.Sprintf("--%s=%s", key, value))
+	// Add parameters as command line arguments, in a stable order
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		cmd.Args = append(cmd.Args, fmt.Sprintf("--%s=%s", key, params[key]))
 	}
 
 	// Set working directory
 	cmd.Dir = r.projectRoot
 
-	return cmd, nil
+	return cmd
 }
 
 func (r *Runner) monitorProcess(ctx context.Context, proc *process.Process, metricsChan chan<- ProcessMetrics) {
@@ -534,53 +664,3 @@ func (r *Runner) monitorProcess(ctx context.Context, proc *process.Process, metr
 		}
 	}
 }
-
-// measureBuildTime measures the time to build/compile for compiled languages
-func (r *Runner) measureBuildTime(tech string, testName string) (float64, error) {
-	// Only measure build time for compiled languages
-	if tech != "go" {
-		return 0, nil // No build time for interpreted languages
-	}
-
-	// For Go, measure "go build" time
-	if tech == "go" {
-		// Find the benchmark directory
-		benchmark, err := r.config.GetBenchmark(tech, testName)
-		if err != nil {
-			return 0, err
-		}
-
-		// Extract directory from command - e.g., ["go", "run", "benchmarks/go/file_read/main.go"]
-		if len(benchmark.Command) < 3 {
-			return 0, fmt.Errorf("invalid go command structure")
-		}
-
-		mainFile := benchmark.Command[2]   // "benchmarks/go/file_read/main.go"
-		buildDir := filepath.Dir(mainFile) // "benchmarks/go/file_read"
-		buildPath := filepath.Join(r.projectRoot, buildDir)
-
-		// Change to build directory
-		originalDir, _ := os.Getwd()
-		if err := os.Chdir(buildPath); err != nil {
-			return 0, err
-		}
-		defer os.Chdir(originalDir)
-
-		// Measure build time
-		startTime := time.Now()
-		cmd := exec.Command("go", "build", "-o", "benchmark_temp", ".")
-		err = cmd.Run()
-		buildTime := time.Since(startTime)
-
-		// Clean up temporary binary
-		os.Remove("benchmark_temp")
-
-		if err != nil {
-			return 0, fmt.Errorf("go build failed: %v", err)
-		}
-
-		return float64(buildTime.Nanoseconds()) / 1e6, nil // Convert to milliseconds
-	}
-
-	return 0, nil
-}
@@ -0,0 +1,46 @@
+//go:build !windows
+
+package runner
+
+import (
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+func setProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+}
+
+// terminateProcessGroup sends SIGTERM to cmd's process group and waits up to
+// timeout for the group to be gone. exited is closed once cmd has been reaped;
+// until then the leader still counts as a member of the group.
+func terminateProcessGroup(cmd *exec.Cmd, exited <-chan struct{}, timeout time.Duration) {
+	if err := syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM); err != nil {
+		return
+	}
+
+	deadline := time.After(timeout)
+	select {
+	case <-exited:
+	case <-deadline:
+		return
+	}
+	for syscall.Kill(-cmd.Process.Pid, 0) == nil {
+		select {
+		case <-deadline:
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// killProcessGroup kills cmd and every process it spawned.
+func killProcessGroup(cmd *exec.Cmd) {
+	if err := syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL); err != nil {
+		cmd.Process.Kill()
+	}
+}
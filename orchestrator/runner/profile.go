@@ -0,0 +1,183 @@
+package runner
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"performance-benchmark-suite/orchestrator/config"
+	"performance-benchmark-suite/orchestrator/report"
+)
+
+// profileStopTimeout is how long a profiled server gets to write its
+// profiles after SIGTERM before it is killed.
+const profileStopTimeout = 10 * time.Second
+
+// profiling holds where profiles of the current run are written. Each trial
+// gets <dir>/<tech>/<test>/<sweep>/run-<n>/<kind>/, without the <sweep> level
+// when the test is not swept, and a fixture it depends on a further
+// <fixture>/ level below the trial's directory.
+type profiling struct {
+	kinds     []string
+	reportDir string
+	dir       string
+
+	// trial is the trial RunBenchmark is running
+	trial Trial
+}
+
+// EnableProfiling makes every benchmark command collect the given kinds of
+// profile, using the flags each technology declares in its configuration.
+// Artifacts go to a profiles_<timestamp> directory inside reportDir.
+func (r *Runner) EnableProfiling(kinds []string, reportDir string) (string, error) {
+	for _, kind := range kinds {
+		if !contains(config.ProfileKinds, kind) {
+			return "", fmt.Errorf("invalid profile kind: %s (use %s)", kind, strings.Join(config.ProfileKinds, ", "))
+		}
+	}
+
+	reportDir, err := filepath.Abs(reportDir)
+	if err != nil {
+		return "", fmt.Errorf("invalid output directory: %v", err)
+	}
+	dir := filepath.Join(reportDir, "profiles_"+time.Now().UTC().Format("2006-01-02T15-04-05Z"))
+
+	// The directories are created as benchmarks start, so a dry run leaves
+	// nothing behind
+	r.profiling = &profiling{kinds: kinds, reportDir: reportDir, dir: dir}
+	return dir, nil
+}
+
+// ProfileDir is the directory profiles are written to, or empty when
+// profiling is off.
+func (r *Runner) ProfileDir() string {
+	if r.profiling == nil {
+		return ""
+	}
+	return r.profiling.dir
+}
+
+// applyProfiling adds the technology's profiling flags to cmd, which runs
+// test as part of the current trial.
+func (r *Runner) applyProfiling(cmd *exec.Cmd, tech, test string) error {
+	args, env, dirs, unsupported, err := r.profileFlags(r.profiling.trial, test)
+	if err != nil {
+		return err
+	}
+	for _, kind := range unsupported {
+		slog.Warn("profiling not supported, skipping it", "tech", tech, "kind", kind)
+	}
+	for _, dir := range dirs {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create profile directory: %v", err)
+		}
+	}
+	if len(env) > 0 {
+		if cmd.Env == nil {
+			cmd.Env = os.Environ()
+		}
+		cmd.Env = append(cmd.Env, env...)
+	}
+
+	cmd.Args = append(cmd.Args[:1], append(args, cmd.Args[1:]...)...)
+	return nil
+}
+
+// profileFlags returns the arguments and environment that make the command
+// of test, run for trial, write profiles, the directories they write them to
+// and the requested kinds the trial's technology has no flags for.
+func (r *Runner) profileFlags(trial Trial, test string) (args, env, dirs, unsupported []string, err error) {
+	technology, err := r.config.GetTechnology(trial.Tech)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	dir := r.profiling.trialDir(trial)
+	if test != trial.Test {
+		dir = filepath.Join(dir, test)
+	}
+
+	for _, kind := range r.profiling.kinds {
+		flags, ok := technology.Profile[kind]
+		if !ok {
+			unsupported = append(unsupported, kind)
+			continue
+		}
+
+		kindDir := filepath.Join(dir, kind)
+		dirs = append(dirs, kindDir)
+		for _, arg := range flags.Args {
+			args = append(args, strings.ReplaceAll(arg, "{dir}", kindDir))
+		}
+		for _, value := range flags.Env {
+			env = append(env, strings.ReplaceAll(value, "{dir}", kindDir))
+		}
+	}
+	return args, env, dirs, unsupported, nil
+}
+
+// trialDir is the directory profiles of trial go to.
+func (p *profiling) trialDir(trial Trial) string {
+	dir := filepath.Join(p.dir, trial.Tech, trial.Test)
+	if len(trial.Sweep) > 0 {
+		dir = filepath.Join(dir, sweepDirName(trial.Sweep))
+	}
+	return filepath.Join(dir, fmt.Sprintf("run-%d", trial.Run))
+}
+
+// sweepDirName names a sweep combination as key=value pairs in key order,
+// joined by commas, with path separators replaced.
+func sweepDirName(sweep map[string]string) string {
+	pairs := make([]string, 0, len(sweep))
+	for _, key := range sortedKeys(sweep) {
+		pairs = append(pairs, key+"="+sweep[key])
+	}
+	return strings.NewReplacer("/", "_", "\\", "_").Replace(strings.Join(pairs, ","))
+}
+
+// collectProfiles lists the profiles written for the current trial, relative
+// to the report directory.
+func (r *Runner) collectProfiles(tech, test string) []report.ProfileArtifact {
+	root := r.profiling.trialDir(r.profiling.trial)
+
+	var artifacts []report.ProfileArtifact
+	filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil
+		}
+
+		// <kind>/<file> for the benchmark itself, <fixture>/<kind>/<file> for a fixture
+		parts := strings.Split(filepath.ToSlash(rel), "/")
+		artifact := report.ProfileArtifact{Kind: parts[0], Process: test}
+		if len(parts) > 2 {
+			artifact.Process, artifact.Kind = parts[0], parts[1]
+		}
+		artifact.Path, _ = filepath.Rel(r.profiling.reportDir, path)
+		artifacts = append(artifacts, artifact)
+		return nil
+	})
+
+	sort.Slice(artifacts, func(i, j int) bool { return artifacts[i].Path < artifacts[j].Path })
+	if len(artifacts) == 0 {
+		slog.Warn("no profiles were written", "tech", tech, "test", test)
+	}
+	return artifacts
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package runner
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"performance-benchmark-suite/orchestrator/config"
+)
+
+func TestSweepDirName(t *testing.T) {
+	tests := []struct {
+		name  string
+		sweep map[string]string
+		want  string
+	}{
+		{name: "single key", sweep: map[string]string{"size": "1024"}, want: "size=1024"},
+		{name: "keys in order", sweep: map[string]string{"workers": "4", "size": "1024"}, want: "size=1024,workers=4"},
+		{name: "path separators", sweep: map[string]string{"file": "test_data/a\\b.json"}, want: "file=test_data_a_b.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sweepDirName(tt.sweep); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProfileTrialDir(t *testing.T) {
+	p := &profiling{dir: filepath.Join("reports", "profiles")}
+
+	tests := []struct {
+		name  string
+		trial Trial
+		want  string
+	}{
+		{
+			name:  "not swept",
+			trial: Trial{Tech: "go", Test: "json_read", Run: 2},
+			want:  filepath.Join("reports", "profiles", "go", "json_read", "run-2"),
+		},
+		{
+			name:  "swept",
+			trial: Trial{Tech: "go", Test: "file_write", Run: 1, Sweep: map[string]string{"size": "1024"}},
+			want:  filepath.Join("reports", "profiles", "go", "file_write", "size=1024", "run-1"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.trialDir(tt.trial); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProfileFlags(t *testing.T) {
+	dir := filepath.Join("reports", "profiles")
+	r := &Runner{
+		profiling: &profiling{kinds: []string{"cpu", "heap"}, dir: dir},
+		config: &config.Config{Technologies: map[string]config.Technology{
+			"go": {Profile: map[string]config.ProfileFlags{
+				"cpu": {Args: []string{"--cpuprofile={dir}/cpu.pprof"}},
+			}},
+			"node": {Profile: map[string]config.ProfileFlags{
+				"cpu":  {Args: []string{"--cpu-prof", "--cpu-prof-dir={dir}"}},
+				"heap": {Env: []string{"NODE_HEAP_DIR={dir}"}},
+			}},
+		}},
+	}
+	trial := Trial{Tech: "node", Test: "http_client", Run: 1}
+	trialDir := filepath.Join(dir, "node", "http_client", "run-1")
+
+	args, env, dirs, unsupported, err := r.profileFlags(trial, "http_client")
+	if err != nil {
+		t.Fatalf("profileFlags: %v", err)
+	}
+	if want := []string{"--cpu-prof", "--cpu-prof-dir=" + filepath.Join(trialDir, "cpu")}; !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %q, want %q", args, want)
+	}
+	if want := []string{"NODE_HEAP_DIR=" + filepath.Join(trialDir, "heap")}; !reflect.DeepEqual(env, want) {
+		t.Errorf("env = %q, want %q", env, want)
+	}
+	if want := []string{filepath.Join(trialDir, "cpu"), filepath.Join(trialDir, "heap")}; !reflect.DeepEqual(dirs, want) {
+		t.Errorf("dirs = %q, want %q", dirs, want)
+	}
+	if len(unsupported) != 0 {
+		t.Errorf("unsupported = %q, want none", unsupported)
+	}
+
+	// A fixture the trial starts profiles below the trial's directory
+	_, _, dirs, _, err = r.profileFlags(trial, "http_server")
+	if err != nil {
+		t.Fatalf("profileFlags: %v", err)
+	}
+	if want := filepath.Join(trialDir, "http_server", "cpu"); len(dirs) == 0 || dirs[0] != want {
+		t.Errorf("fixture dirs = %q, want %s first", dirs, want)
+	}
+
+	_, _, _, unsupported, err = r.profileFlags(Trial{Tech: "go", Test: "json_read", Run: 1}, "json_read")
+	if err != nil {
+		t.Fatalf("profileFlags: %v", err)
+	}
+	if want := []string{"heap"}; !reflect.DeepEqual(unsupported, want) {
+		t.Errorf("unsupported = %q, want %q", unsupported, want)
+	}
+
+	if _, _, _, _, err := r.profileFlags(Trial{Tech: "rust", Test: "json_read", Run: 1}, "json_read"); err == nil {
+		t.Error("expected an error for an unknown technology")
+	}
+}
@@ -0,0 +1,44 @@
+//go:build !windows
+
+package runner
+
+import (
+	"os"
+	"runtime"
+	"syscall"
+	"time"
+
+	"performance-benchmark-suite/orchestrator/report"
+)
+
+// resourceUsage converts the rusage wait4 returned for an exited process.
+// The kernel includes every descendant the process waited for, so launchers
+// such as `go run` or `npm run` count together with the benchmark they ran.
+func resourceUsage(state *os.ProcessState, wall time.Duration) *report.ResourceUsage {
+	if state == nil {
+		return nil
+	}
+	rusage, ok := state.SysUsage().(*syscall.Rusage)
+	if !ok || rusage == nil {
+		return nil
+	}
+
+	// ru_maxrss is in kilobytes on Linux and in bytes on macOS
+	maxRSS := float64(rusage.Maxrss) / 1024
+	if runtime.GOOS == "darwin" {
+		maxRSS /= 1024
+	}
+
+	return &report.ResourceUsage{
+		WallTimeMs:                 durationMs(wall),
+		UserCPUMs:                  durationMs(time.Duration(rusage.Utime.Nano())),
+		SystemCPUMs:                durationMs(time.Duration(rusage.Stime.Nano())),
+		MaxRSSMB:                   maxRSS,
+		MinorPageFaults:            int64(rusage.Minflt),
+		MajorPageFaults:            int64(rusage.Majflt),
+		VoluntaryContextSwitches:   int64(rusage.Nvcsw),
+		InvoluntaryContextSwitches: int64(rusage.Nivcsw),
+		BlockInputOps:              int64(rusage.Inblock),
+		BlockOutputOps:             int64(rusage.Oublock),
+	}
+}
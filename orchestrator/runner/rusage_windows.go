@@ -0,0 +1,15 @@
+//go:build windows
+
+package runner
+
+import (
+	"os"
+	"time"
+
+	"performance-benchmark-suite/orchestrator/report"
+)
+
+// resourceUsage is unavailable on Windows; results keep the sampled metrics.
+func resourceUsage(state *os.ProcessState, wall time.Duration) *report.ResourceUsage {
+	return nil
+}
@@ -0,0 +1,194 @@
+package runner
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// serverProcess is a benchmark server started by the runner. It keeps the
+// server output around so failures can be reported with context.
+type serverProcess struct {
+	tech   string
+	cmd    *exec.Cmd
+	proc   *process.Process
+	stdout *logBuffer
+	stderr *logBuffer
+
+	// interrupt is told when the server is stopped
+	interrupt *interrupt
+
+	// graceful is how long the server gets to exit on SIGTERM before it is
+	// killed; zero kills it straight away
+	graceful time.Duration
+}
+
+// logBuffer is a strings.Builder that is safe to write from the pipe
+// readers while the runner reads it for error messages.
+type logBuffer struct {
+	mu  sync.Mutex
+	buf strings.Builder
+}
+
+func (b *logBuffer) WriteLine(line string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf.WriteString(line + "\n")
+}
+
+func (b *logBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// startServer starts cmd, streams its output with a per-technology prefix and
+// polls ready until the server reports that it accepts traffic.
+func (r *Runner) startServer(tech string, cmd *exec.Cmd, ready func() error) (*serverProcess, error) {
+	// Capture server stdout and stderr for debugging
+	serverStdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server stdout pipe: %v", err)
+	}
+
+	serverStderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server stderr pipe: %v", err)
+	}
+
+	server := &serverProcess{
+		tech:      tech,
+		cmd:       cmd,
+		stdout:    &logBuffer{},
+		stderr:    &logBuffer{},
+		interrupt: &r.interrupt,
+	}
+	// Profiled servers write their profiles when they exit
+	if r.profiling != nil {
+		server.graceful = profileStopTimeout
+	}
+
+	go streamServerOutput(serverStdout, tech, "stdout", server.stdout)
+	go streamServerOutput(serverStderr, tech, "stderr", server.stderr)
+
+	// Start the server in its own process group so launchers such as
+	// `go run` do not leave the real server behind when stopped
+	setProcessGroup(cmd)
+	slog.Info("starting server", "tech", tech, "command", strings.Join(cmd.Args, " "))
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start %s server: %v", tech, err)
+	}
+	r.interrupt.started(cmd)
+
+	// Setup process monitoring for the server
+	proc, err := process.NewProcess(int32(cmd.Process.Pid))
+	if err != nil {
+		server.stop()
+		return nil, fmt.Errorf("failed to get server process: %v", err)
+	}
+	server.proc = proc
+
+	// Health check with retries
+	maxRetries := 30 // 15 seconds total
+	slog.Info("waiting for server health check", "tech", tech)
+
+	for i := 0; i < maxRetries; i++ {
+		time.Sleep(500 * time.Millisecond)
+
+		// Check if server process is still running
+		if !server.alive() {
+			server.stop()
+			return nil, fmt.Errorf("server process died during startup - stdout: %s, stderr: %s",
+				server.stdout.String(), server.stderr.String())
+		}
+
+		// Try health check
+		if err := ready(); err == nil {
+			slog.Info("server is ready", "tech", tech, "pid", cmd.Process.Pid)
+			return server, nil
+		}
+	}
+
+	server.stop()
+	return nil, fmt.Errorf("server health check failed after %d retries - stdout: %s, stderr: %s",
+		maxRetries, server.stdout.String(), server.stderr.String())
+}
+
+// httpHealthCheck returns a readiness check that expects a 200 from url.
+func httpHealthCheck(url string) func() error {
+	client := &http.Client{Timeout: 1 * time.Second}
+	return func() error {
+		resp, err := client.Get(url)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("health check returned status %d", resp.StatusCode)
+		}
+		return nil
+	}
+}
+
+// alive reports whether the server process can still be signalled. A
+// process that exited but has not been reaped yet counts as dead.
+func (s *serverProcess) alive() bool {
+	if s.cmd.Process == nil {
+		return false
+	}
+	if s.proc != nil {
+		if status, err := s.proc.Status(); err == nil && len(status) > 0 && status[0] == process.Zombie {
+			return false
+		}
+	}
+	proc, err := os.FindProcess(s.cmd.Process.Pid)
+	if err != nil {
+		return false
+	}
+	return proc.Signal(syscall.Signal(0)) == nil
+}
+
+// stop kills the server's process group and reaps the process. With a
+// grace period the group is asked to exit with SIGTERM first.
+func (s *serverProcess) stop() {
+	if s.cmd.Process == nil {
+		return
+	}
+	slog.Info("stopping server", "tech", s.tech)
+
+	if s.graceful > 0 {
+		exited := make(chan struct{})
+		go func() {
+			s.cmd.Wait()
+			close(exited)
+		}()
+		terminateProcessGroup(s.cmd, exited, s.graceful)
+		killProcessGroup(s.cmd)
+		<-exited
+		s.interrupt.stopped(s.cmd)
+		return
+	}
+
+	killProcessGroup(s.cmd)
+	s.cmd.Wait() // Clean up zombie process
+	s.interrupt.stopped(s.cmd)
+}
+
+func streamServerOutput(pipe io.Reader, tech, stream string, buf *logBuffer) {
+	scanner := bufio.NewScanner(pipe)
+	for scanner.Scan() {
+		line := scanner.Text()
+		slog.Debug("server output", "tech", tech, "stream", stream, "line", line)
+		buf.WriteLine(line)
+	}
+}
@@ -0,0 +1,71 @@
+package runner
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// LatencySummary holds the distribution of a set of latency samples in milliseconds.
+type LatencySummary struct {
+	Count int
+	AvgMs float64
+	MinMs float64
+	MaxMs float64
+	P50Ms float64
+	P75Ms float64
+	P90Ms float64
+	P95Ms float64
+	P99Ms float64
+}
+
+// summarizeDurations sorts the samples and computes the usual percentiles.
+func summarizeDurations(samples []time.Duration) LatencySummary {
+	ms := make([]float64, len(samples))
+	for i, d := range samples {
+		ms[i] = float64(d.Nanoseconds()) / 1e6
+	}
+	return summarizeMs(ms)
+}
+
+func summarizeMs(samples []float64) LatencySummary {
+	if len(samples) == 0 {
+		return LatencySummary{}
+	}
+
+	sorted := make([]float64, len(samples))
+	copy(sorted, samples)
+	sort.Float64s(sorted)
+
+	var total float64
+	for _, v := range sorted {
+		total += v
+	}
+
+	return LatencySummary{
+		Count: len(sorted),
+		AvgMs: total / float64(len(sorted)),
+		MinMs: sorted[0],
+		MaxMs: sorted[len(sorted)-1],
+		P50Ms: percentile(sorted, 50),
+		P75Ms: percentile(sorted, 75),
+		P90Ms: percentile(sorted, 90),
+		P95Ms: percentile(sorted, 95),
+		P99Ms: percentile(sorted, 99),
+	}
+}
+
+// percentile returns the nearest-rank percentile p (0-100) of an already sorted slice.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
@@ -0,0 +1,40 @@
+package runner
+
+import (
+	"sort"
+)
+
+// SweepCombinations returns the cartesian product of the values of a sweep,
+// one parameter set per combination. Parameters vary in key order with the
+// last key changing fastest, so the sets come out grouped by the first key.
+// An empty sweep has no combinations.
+func SweepCombinations(sweep map[string][]string) []map[string]string {
+	keys := make([]string, 0, len(sweep))
+	for key, values := range sweep {
+		if len(values) == 0 {
+			return nil
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	sort.Strings(keys)
+
+	combinations := []map[string]string{{}}
+	for _, key := range keys {
+		next := make([]map[string]string, 0, len(combinations)*len(sweep[key]))
+		for _, combination := range combinations {
+			for _, value := range sweep[key] {
+				set := make(map[string]string, len(combination)+1)
+				for k, v := range combination {
+					set[k] = v
+				}
+				set[key] = value
+				next = append(next, set)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
@@ -0,0 +1,60 @@
+package runner
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSweepCombinations(t *testing.T) {
+	tests := []struct {
+		name  string
+		sweep map[string][]string
+		want  []map[string]string
+	}{
+		{
+			name:  "single key keeps value order",
+			sweep: map[string][]string{"size": {"1048576", "1024", "65536"}},
+			want:  []map[string]string{{"size": "1048576"}, {"size": "1024"}, {"size": "65536"}},
+		},
+		{
+			name:  "last key changes fastest",
+			sweep: map[string][]string{"workers": {"1", "4"}, "size": {"a", "b", "c"}},
+			want: []map[string]string{
+				{"size": "a", "workers": "1"},
+				{"size": "a", "workers": "4"},
+				{"size": "b", "workers": "1"},
+				{"size": "b", "workers": "4"},
+				{"size": "c", "workers": "1"},
+				{"size": "c", "workers": "4"},
+			},
+		},
+		{
+			name:  "three keys",
+			sweep: map[string][]string{"c": {"1", "2"}, "a": {"x"}, "b": {"p", "q"}},
+			want: []map[string]string{
+				{"a": "x", "b": "p", "c": "1"},
+				{"a": "x", "b": "p", "c": "2"},
+				{"a": "x", "b": "q", "c": "1"},
+				{"a": "x", "b": "q", "c": "2"},
+			},
+		},
+		{
+			name:  "empty sweep",
+			sweep: map[string][]string{},
+			want:  nil,
+		},
+		{
+			name:  "key without values",
+			sweep: map[string][]string{"size": {"1"}, "workers": {}},
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SweepCombinations(tt.sweep); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %v\nwant %v", got, tt.want)
+			}
+		})
+	}
+}
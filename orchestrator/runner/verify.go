@@ -0,0 +1,115 @@
+package runner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"performance-benchmark-suite/orchestrator/config"
+	"performance-benchmark-suite/orchestrator/report"
+)
+
+// Verification states recorded on results by VerifyDigests
+const (
+	VerificationVerified     = "verified"
+	VerificationMismatch     = "mismatch"
+	VerificationInconclusive = "inconclusive"
+	VerificationUnverified   = "unverified"
+)
+
+// DigestMismatch describes a result whose work digest disagrees with the
+// other technologies that ran the same test and parameters. Expected is empty
+// when no digest has a majority and the result is inconclusive.
+type DigestMismatch struct {
+	Tech     string
+	Test     string
+	Digest   string
+	Expected string
+}
+
+// VerifyDigests compares the work digests of results that share a test,
+// effective parameters, sweep combination and run, and sets each result's
+// Verification. The digest reported by most technologies is taken as the
+// reference; when there is no single most common digest, every result in the
+// group is inconclusive. Results without a digest, or without another
+// technology to compare against, stay unverified.
+func VerifyDigests(cfg *config.Config, results []report.BenchmarkResult) []DigestMismatch {
+	groups := make(map[string][]int)
+	var keys []string
+	for i, result := range results {
+		if result.Digest == "" {
+			results[i].Verification = VerificationUnverified
+			continue
+		}
+		key := digestGroupKey(cfg, result)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], i)
+	}
+
+	var mismatches []DigestMismatch
+	for _, key := range keys {
+		indexes := groups[key]
+		if len(indexes) < 2 {
+			results[indexes[0]].Verification = VerificationUnverified
+			continue
+		}
+
+		counts := make(map[string]int)
+		for _, i := range indexes {
+			counts[results[i].Digest]++
+		}
+		reference, best, tied := "", 0, false
+		for digest, count := range counts {
+			switch {
+			case count > best:
+				reference, best, tied = digest, count, false
+			case count == best:
+				tied = true
+			}
+		}
+		if tied {
+			reference = ""
+		}
+
+		for _, i := range indexes {
+			switch {
+			case results[i].Digest == reference:
+				results[i].Verification = VerificationVerified
+				continue
+			case tied:
+				results[i].Verification = VerificationInconclusive
+			default:
+				results[i].Verification = VerificationMismatch
+			}
+			mismatches = append(mismatches, DigestMismatch{
+				Tech:     results[i].Tech,
+				Test:     results[i].Test,
+				Digest:   results[i].Digest,
+				Expected: reference,
+			})
+		}
+	}
+	return mismatches
+}
+
+// digestGroupKey identifies results that are expected to do identical work:
+// the same test run with the same parameters once each technology's defaults
+// are applied, in the same sweep combination and run.
+func digestGroupKey(cfg *config.Config, result report.BenchmarkResult) string {
+	params := result.Parameters
+	if benchmark, err := cfg.GetBenchmark(result.Tech, result.Test); err == nil {
+		params = mergeParams(benchmark.DefaultParams, result.Parameters)
+	}
+	return fmt.Sprintf("%s?%s#%s/%d", result.Test, sortedPairs(params), sortedPairs(result.Sweep), result.Run)
+}
+
+func sortedPairs(values map[string]string) string {
+	pairs := make([]string, 0, len(values))
+	for key, value := range values {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
@@ -0,0 +1,123 @@
+package runner
+
+import (
+	"testing"
+
+	"performance-benchmark-suite/orchestrator/config"
+	"performance-benchmark-suite/orchestrator/report"
+)
+
+func TestVerifyDigests(t *testing.T) {
+	cfg := &config.Config{Technologies: map[string]config.Technology{
+		"go":   {Benchmarks: map[string]config.Benchmark{"json_read": {DefaultParams: map[string]string{"shape": "flat"}}}},
+		"node": {Benchmarks: map[string]config.Benchmark{"json_read": {DefaultParams: map[string]string{"shape": "flat"}}}},
+		"bun":  {Benchmarks: map[string]config.Benchmark{"json_read": {DefaultParams: map[string]string{"shape": "nested"}}}},
+	}}
+	result := func(tech, digest string) report.BenchmarkResult {
+		return report.BenchmarkResult{Tech: tech, Test: "json_write", Digest: digest}
+	}
+
+	tests := []struct {
+		name       string
+		results    []report.BenchmarkResult
+		want       []string
+		mismatches int
+		expected   string
+	}{
+		{
+			name:    "all agree",
+			results: []report.BenchmarkResult{result("go", "a"), result("node", "a"), result("bun", "a")},
+			want:    []string{VerificationVerified, VerificationVerified, VerificationVerified},
+		},
+		{
+			name:       "majority wins",
+			results:    []report.BenchmarkResult{result("go", "a"), result("node", "b"), result("bun", "a")},
+			want:       []string{VerificationVerified, VerificationMismatch, VerificationVerified},
+			mismatches: 1,
+			expected:   "a",
+		},
+		{
+			name:       "tie is inconclusive",
+			results:    []report.BenchmarkResult{result("go", "a"), result("node", "b")},
+			want:       []string{VerificationInconclusive, VerificationInconclusive},
+			mismatches: 2,
+		},
+		{
+			name: "tie between the most common digests",
+			results: []report.BenchmarkResult{
+				result("go", "a"), result("node", "b"), result("bun", "a"), result("deno", "b"), result("hono", "c"),
+			},
+			want: []string{
+				VerificationInconclusive, VerificationInconclusive, VerificationInconclusive, VerificationInconclusive, VerificationInconclusive,
+			},
+			mismatches: 5,
+		},
+		{
+			name:    "single result",
+			results: []report.BenchmarkResult{result("go", "a")},
+			want:    []string{VerificationUnverified},
+		},
+		{
+			name:    "missing digest",
+			results: []report.BenchmarkResult{result("go", "a"), result("node", ""), result("bun", "a")},
+			want:    []string{VerificationVerified, VerificationUnverified, VerificationVerified},
+		},
+		{
+			name: "parameters split groups",
+			results: []report.BenchmarkResult{
+				{Tech: "go", Test: "json_write", Digest: "a", Parameters: map[string]string{"size": "10"}},
+				{Tech: "node", Test: "json_write", Digest: "b", Parameters: map[string]string{"size": "20"}},
+			},
+			want: []string{VerificationUnverified, VerificationUnverified},
+		},
+		{
+			name: "defaults are part of the parameters",
+			results: []report.BenchmarkResult{
+				{Tech: "go", Test: "json_read", Digest: "a"},
+				{Tech: "node", Test: "json_read", Digest: "a", Parameters: map[string]string{"shape": "flat"}},
+				{Tech: "bun", Test: "json_read", Digest: "b"},
+			},
+			want: []string{VerificationVerified, VerificationVerified, VerificationUnverified},
+		},
+		{
+			name: "sweep combinations split groups",
+			results: []report.BenchmarkResult{
+				{Tech: "go", Test: "json_write", Digest: "a", Sweep: map[string]string{"size": "10"}},
+				{Tech: "node", Test: "json_write", Digest: "b", Sweep: map[string]string{"size": "20"}},
+			},
+			want: []string{VerificationUnverified, VerificationUnverified},
+		},
+		{
+			name: "runs split groups",
+			results: []report.BenchmarkResult{
+				{Tech: "go", Test: "json_write", Digest: "a", Run: 1},
+				{Tech: "node", Test: "json_write", Digest: "a", Run: 1},
+				{Tech: "go", Test: "json_write", Digest: "a", Run: 2},
+				{Tech: "node", Test: "json_write", Digest: "b", Run: 2},
+			},
+			want: []string{
+				VerificationVerified, VerificationVerified, VerificationInconclusive, VerificationInconclusive,
+			},
+			mismatches: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mismatches := VerifyDigests(cfg, tt.results)
+			for i, result := range tt.results {
+				if result.Verification != tt.want[i] {
+					t.Errorf("%s: verification %q, want %q", result.Tech, result.Verification, tt.want[i])
+				}
+			}
+			if len(mismatches) != tt.mismatches {
+				t.Fatalf("got %d mismatches, want %d", len(mismatches), tt.mismatches)
+			}
+			for _, mismatch := range mismatches {
+				if mismatch.Expected != tt.expected {
+					t.Errorf("%s: expected digest %q, want %q", mismatch.Tech, mismatch.Expected, tt.expected)
+				}
+			}
+		})
+	}
+}
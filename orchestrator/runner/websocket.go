@@ -0,0 +1,265 @@
+package runner
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"performance-benchmark-suite/orchestrator/report"
+
+	"github.com/gorilla/websocket"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// wsConn is one client connection of the WebSocket driver together with the
+// round-trip latencies it observed.
+type wsConn struct {
+	conn      *websocket.Conn
+	writeMu   sync.Mutex
+	latencies []time.Duration
+}
+
+// webSocketExecutor starts a WebSocket server and drives it with the
+// orchestrator's own clients, each sending timestamped messages at a fixed
+// rate and timing how long they take to come back.
+type webSocketExecutor struct {
+	params      map[string]string
+	connections int
+	rate        float64
+	duration    time.Duration
+	messageSize int
+	mode        string
+	port        int
+	server      *serverProcess
+	conns       []*wsConn
+}
+
+func (e *webSocketExecutor) Prepare(run *BenchmarkRun) error {
+	slog.Info("starting WebSocket benchmark", "tech", run.Tech, "test", run.Test)
+	return e.configure(run)
+}
+
+// configure resolves the client parameters and port for both Prepare and Plan.
+func (e *webSocketExecutor) configure(run *BenchmarkRun) error {
+	e.params = mergeParams(run.Benchmark.DefaultParams, run.Params)
+
+	var err error
+	if e.connections, err = paramInt(e.params, "connections", 100); err != nil {
+		return err
+	}
+	if e.rate, err = paramFloat(e.params, "rate", 10); err != nil {
+		return err
+	}
+	if e.duration, err = paramDuration(e.params, "duration", 10*time.Second); err != nil {
+		return err
+	}
+	if e.messageSize, err = paramInt(e.params, "message-size", 64); err != nil {
+		return err
+	}
+	e.mode = e.params["mode"]
+	if e.mode == "" {
+		e.mode = "echo"
+	}
+	if e.mode != "echo" && e.mode != "broadcast" {
+		return fmt.Errorf("invalid mode parameter: %s (use 'echo' or 'broadcast')", e.mode)
+	}
+	if e.connections < 1 || e.rate <= 0 {
+		return fmt.Errorf("connections and rate must be positive")
+	}
+	// The first 8 bytes of every message carry the send timestamp
+	if e.messageSize < 8 {
+		e.messageSize = 8
+	}
+
+	e.port = run.Benchmark.Port
+	if e.port == 0 {
+		e.port = 3000
+	}
+	return nil
+}
+
+func (e *webSocketExecutor) Plan(run *BenchmarkRun, planned *PlannedTrial) {
+	if err := e.configure(run); err != nil {
+		planned.skip(SkipInvalidParams, "%v", err)
+		return
+	}
+	run.runner.planCommand(planned, "server", run.Test, map[string]string{"port": fmt.Sprintf("%d", e.port)}, nil)
+	planned.Client = fmt.Sprintf("built-in websocket client, %d connections in %s mode against localhost:%d", e.connections, e.mode, e.port)
+	planned.Ports = []int{e.port}
+}
+
+func (e *webSocketExecutor) Start(run *BenchmarkRun) error {
+	// Only the port is passed to the server; the other parameters drive the client
+	serverCmd, err := run.runner.buildBenchmarkCommand(run.Tech, run.Test, map[string]string{"port": fmt.Sprintf("%d", e.port)})
+	if err != nil {
+		return fmt.Errorf("failed to build server command: %v", err)
+	}
+
+	e.server, err = run.runner.startServer(run.Tech, serverCmd, httpHealthCheck(fmt.Sprintf("http://localhost:%d/health", e.port)))
+	if err != nil {
+		return err
+	}
+	run.Monitor(e.server.proc)
+	return nil
+}
+
+func (e *webSocketExecutor) Measure(run *BenchmarkRun) (*report.BenchmarkResult, error) {
+	tech := run.Tech
+	baselineRSS := processTreeRSS(e.server.proc)
+
+	// Open all connections up front so setup time and per-connection memory
+	// are measured separately from message traffic
+	url := fmt.Sprintf("ws://localhost:%d/%s", e.port, e.mode)
+	slog.Info("opening WebSocket connections", "tech", tech, "connections", e.connections, "url", url)
+
+	dialer := websocket.Dialer{HandshakeTimeout: 5 * time.Second}
+	setupTimes := make([]time.Duration, 0, e.connections)
+	for i := 0; i < e.connections; i++ {
+		dialStart := time.Now()
+		conn, _, err := dialer.Dial(url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open connection %d: %v, server stderr: %s", i+1, err, e.server.stderr.String())
+		}
+		setupTimes = append(setupTimes, time.Since(dialStart))
+		e.conns = append(e.conns, &wsConn{conn: conn})
+	}
+
+	// Let the server settle before sampling its memory
+	time.Sleep(500 * time.Millisecond)
+	var memoryPerConnKB float64
+	if rss := processTreeRSS(e.server.proc); rss > baselineRSS {
+		memoryPerConnKB = float64(rss-baselineRSS) / 1024 / float64(e.connections)
+	}
+
+	slog.Info("sending messages", "tech", tech, "mode", e.mode, "rate", e.rate, "size", e.messageSize, "duration", e.duration.String())
+
+	epoch := time.Now()
+	var sent, received, failures int64
+	var readers, writers sync.WaitGroup
+	stop := make(chan struct{})
+
+	for _, c := range e.conns {
+		readers.Add(1)
+		go func(c *wsConn) {
+			defer readers.Done()
+			for {
+				_, data, err := c.conn.ReadMessage()
+				if err != nil {
+					return
+				}
+				if len(data) < 8 {
+					continue
+				}
+				sentAt := time.Duration(binary.BigEndian.Uint64(data[:8]))
+				c.latencies = append(c.latencies, time.Since(epoch)-sentAt)
+				atomic.AddInt64(&received, 1)
+			}
+		}(c)
+
+		writers.Add(1)
+		go func(c *wsConn) {
+			defer writers.Done()
+			ticker := time.NewTicker(time.Duration(float64(time.Second) / e.rate))
+			defer ticker.Stop()
+
+			payload := make([]byte, e.messageSize)
+			for i := 8; i < len(payload); i++ {
+				payload[i] = 'x'
+			}
+
+			for {
+				select {
+				case <-stop:
+					return
+				case <-ticker.C:
+					binary.BigEndian.PutUint64(payload[:8], uint64(time.Since(epoch)))
+					c.writeMu.Lock()
+					err := c.conn.WriteMessage(websocket.BinaryMessage, payload)
+					c.writeMu.Unlock()
+					if err != nil {
+						atomic.AddInt64(&failures, 1)
+						return
+					}
+					atomic.AddInt64(&sent, 1)
+				}
+			}
+		}(c)
+	}
+
+	time.Sleep(e.duration)
+	close(stop)
+	writers.Wait()
+	sendWindow := time.Since(epoch)
+
+	// Give in-flight messages a moment to come back before closing
+	time.Sleep(time.Second)
+	for _, c := range e.conns {
+		c.conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+		c.conn.Close()
+	}
+	readers.Wait()
+
+	processMetrics := run.Metrics()
+
+	var latencies []time.Duration
+	for _, c := range e.conns {
+		latencies = append(latencies, c.latencies...)
+	}
+	latency := summarizeDurations(latencies)
+	setup := summarizeDurations(setupTimes)
+	messagesPerSecond := float64(received) / sendWindow.Seconds()
+
+	slog.Info("WebSocket benchmark completed", "tech", tech, "sent", sent, "received", received,
+		"writeErrors", failures, "messagesPerSecond", messagesPerSecond, "p99Ms", latency.P99Ms)
+
+	return &report.BenchmarkResult{
+		Tech:       tech,
+		Test:       run.Test,
+		Parameters: e.params,
+		Metrics: report.Metrics{
+			OperationsPerSecond:  messagesPerSecond,
+			MessagesPerSecond:    messagesPerSecond,
+			LatencyAvgMs:         latency.AvgMs,
+			LatencyP50Ms:         latency.P50Ms,
+			LatencyP75Ms:         latency.P75Ms,
+			LatencyP90Ms:         latency.P90Ms,
+			LatencyP95Ms:         latency.P95Ms,
+			LatencyP99Ms:         latency.P99Ms,
+			ConnectionSetupAvgMs: setup.AvgMs,
+			ConnectionSetupP99Ms: setup.P99Ms,
+			MemoryPerConnKB:      memoryPerConnKB,
+			MaxMemoryMB:          processMetrics.MaxMemoryMB,
+			AvgCPUPercent:        processMetrics.AvgCPUPercent,
+		},
+	}, nil
+}
+
+// Teardown closes the client connections, which is harmless when Measure
+// already closed them, and stops the server.
+func (e *webSocketExecutor) Teardown(run *BenchmarkRun) {
+	for _, c := range e.conns {
+		c.conn.Close()
+	}
+	if e.server != nil {
+		e.server.stop()
+	}
+}
+
+// processTreeRSS sums the resident memory of proc and its descendants, since
+// launchers like `go run` keep the actual server in a child process.
+func processTreeRSS(proc *process.Process) uint64 {
+	var total uint64
+	if memInfo, err := proc.MemoryInfo(); err == nil {
+		total += memInfo.RSS
+	}
+	if children, err := proc.Children(); err == nil {
+		for _, child := range children {
+			total += processTreeRSS(child)
+		}
+	}
+	return total
+}
@@ -0,0 +1,60 @@
+package runner
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"performance-benchmark-suite/orchestrator/config"
+)
+
+func TestWebSocketConfigure(t *testing.T) {
+	tests := []struct {
+		name      string
+		benchmark config.Benchmark
+		params    map[string]string
+		want      webSocketExecutor
+		wantErr   bool
+	}{
+		{
+			name: "defaults",
+			want: webSocketExecutor{connections: 100, rate: 10, duration: 10 * time.Second, messageSize: 64, mode: "echo", port: 3000},
+		},
+		{
+			name:      "overrides win over the defaults",
+			benchmark: config.Benchmark{Port: 3001, DefaultParams: map[string]string{"connections": "50", "mode": "broadcast"}},
+			params:    map[string]string{"connections": "10", "rate": "2.5", "duration": "3s"},
+			want:      webSocketExecutor{connections: 10, rate: 2.5, duration: 3 * time.Second, messageSize: 64, mode: "broadcast", port: 3001},
+		},
+		{
+			name:   "messages hold at least the timestamp",
+			params: map[string]string{"message-size": "4"},
+			want:   webSocketExecutor{connections: 100, rate: 10, duration: 10 * time.Second, messageSize: 8, mode: "echo", port: 3000},
+		},
+		{name: "unknown mode", params: map[string]string{"mode": "fanout"}, wantErr: true},
+		{name: "no connections", params: map[string]string{"connections": "0"}, wantErr: true},
+		{name: "negative rate", params: map[string]string{"rate": "-1"}, wantErr: true},
+		{name: "invalid duration", params: map[string]string{"duration": "soon"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &webSocketExecutor{}
+			err := e.configure(&BenchmarkRun{Tech: "go", Test: "websocket_server", Benchmark: &tt.benchmark, Params: tt.params})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("configure: %v", err)
+			}
+			// The merged parameters are covered by mergeParams
+			e.params = nil
+			if !reflect.DeepEqual(*e, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", *e, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package runner
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"performance-benchmark-suite/orchestrator/report"
+
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+// Approximate sizes of what the writers produce, used to estimate how much
+// space a run needs before starting it
+const (
+	textLineBytes = 96
+	jsonLineBytes = 320
+	jsonItemBytes = 256
+
+	// workspaceHeadroom is kept free on top of the expected footprint
+	workspaceHeadroom = 64 << 20
+)
+
+// workspace is a private directory a single benchmark run writes its output
+// into. It is removed when the run finishes, however it finishes.
+type workspace struct {
+	path       string
+	dir        string
+	filesystem string
+	output     string
+	once       sync.Once
+}
+
+// newWorkspace creates an isolated directory under dir for one run after
+// checking the filesystem has room for footprint bytes.
+func newWorkspace(dir, tech, test string, footprint int64) (*workspace, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create workdir: %v", err)
+	}
+
+	usage, err := disk.Usage(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check free space in %s: %v", dir, err)
+	}
+	filesystem := filesystemType(dir, usage.Fstype)
+	if needed := uint64(footprint) + workspaceHeadroom; usage.Free < needed {
+		return nil, fmt.Errorf("not enough free space in %s (%s): %.1fMB free, %s - %s needs about %.1fMB",
+			dir, filesystem, float64(usage.Free)/1024/1024, tech, test, float64(needed)/1024/1024)
+	}
+
+	path, err := os.MkdirTemp(dir, fmt.Sprintf("bench-%s-%s-", tech, test))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workspace: %v", err)
+	}
+
+	ws := &workspace{
+		path:       path,
+		dir:        dir,
+		filesystem: filesystem,
+	}
+	return ws, nil
+}
+
+// filesystemType names the filesystem dir lives on, preferring the type of
+// its mount point since statfs cannot tell ext2, ext3 and ext4 apart.
+func filesystemType(dir, fallback string) string {
+	partitions, err := disk.Partitions(true)
+	if err != nil {
+		return fallback
+	}
+	fstype, longest := fallback, -1
+	for _, partition := range partitions {
+		mount := partition.Mountpoint
+		if dir != mount && !strings.HasPrefix(dir, strings.TrimSuffix(mount, string(filepath.Separator))+string(filepath.Separator)) {
+			continue
+		}
+		if len(mount) > longest {
+			fstype, longest = partition.Fstype, len(mount)
+		}
+	}
+	return fstype
+}
+
+func (ws *workspace) remove() {
+	ws.once.Do(func() {
+		if err := os.RemoveAll(ws.path); err != nil {
+			slog.Warn("failed to remove workspace", "path", ws.path, "error", err)
+		}
+	})
+}
+
+// args returns params with the benchmark's output pointed into the workspace.
+func (ws *workspace) args(params map[string]string) map[string]string {
+	args := mergeParams(params, nil)
+	if ws.output != "" {
+		args["output"] = ws.output
+	}
+	return args
+}
+
+// env points temporary files the benchmark creates at the workspace too.
+func (ws *workspace) env(env []string) []string {
+	if env == nil {
+		env = os.Environ()
+	}
+	return append(env, "TMPDIR="+ws.path, "BENCHMARK_WORKDIR="+ws.path)
+}
+
+func (ws *workspace) info() *report.Workspace {
+	return &report.Workspace{Dir: ws.dir, Filesystem: ws.filesystem}
+}
+
+// prepareWorkspace creates a workspace for benchmarks that write an output
+// file. It returns nil for benchmarks that only read, and when the output
+// path was set explicitly on the command line.
+func (r *Runner) prepareWorkspace(tech, test string, params map[string]string) (*workspace, error) {
+	benchmark, err := r.config.GetBenchmark(tech, test)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get benchmark config: %v", err)
+	}
+
+	defaultOutput, ok := benchmark.DefaultParams["output"]
+	if !ok || params["output"] != "" {
+		return nil, nil
+	}
+
+	ws, err := newWorkspace(r.workdir, tech, test, expectedFootprint(test, mergeParams(benchmark.DefaultParams, params)))
+	if err != nil {
+		return nil, err
+	}
+	ws.output = filepath.Join(ws.path, filepath.Base(defaultOutput))
+	return ws, nil
+}
+
+// expectedFootprint estimates how much a writer benchmark keeps in its
+// workspace at once. Each iteration overwrites the same file, so iterations
+// do not add up.
+func expectedFootprint(test string, params map[string]string) int64 {
+	switch test {
+	case "file_write":
+		size, _ := paramInt(params, "size", 1024)
+		return int64(size)
+	case "file_write_lines":
+		lines, _ := paramInt(params, "lines", 1000000)
+		return int64(lines) * textLineBytes
+	case "json_write":
+		size, _ := paramInt(params, "size", 100)
+		return int64(size) * jsonItemBytes
+	case "json_write_lines":
+		lines, _ := paramInt(params, "lines", 1000000)
+		return int64(lines) * jsonLineBytes
+	case "file_concurrent":
+		workers, _ := paramInt(params, "workers", 4)
+		fileSize, _ := paramInt(params, "file-size", 16*1024*1024)
+		return int64(workers) * int64(fileSize)
+	}
+	return 0
+}
@@ -0,0 +1,92 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"performance-benchmark-suite/orchestrator/config"
+)
+
+func TestPrepareWorkspace(t *testing.T) {
+	r := &Runner{workdir: t.TempDir(), config: &config.Config{Technologies: map[string]config.Technology{
+		"go": {Benchmarks: map[string]config.Benchmark{
+			"file_write": {DefaultParams: map[string]string{"output": "test_data/output.txt", "size": "1024"}},
+			"file_read":  {DefaultParams: map[string]string{"file": "test_data/input.txt"}},
+		}},
+	}}}
+
+	if ws, err := r.prepareWorkspace("go", "file_read", nil); ws != nil || err != nil {
+		t.Errorf("a reading benchmark got workspace %v, error %v", ws, err)
+	}
+	if ws, err := r.prepareWorkspace("go", "file_write", map[string]string{"output": "/tmp/explicit.txt"}); ws != nil || err != nil {
+		t.Errorf("an explicit output got workspace %v, error %v", ws, err)
+	}
+
+	ws, err := r.prepareWorkspace("go", "file_write", map[string]string{"size": "2048"})
+	if err != nil {
+		t.Fatalf("prepareWorkspace: %v", err)
+	}
+	if filepath.Dir(ws.path) != r.workdir || !strings.HasPrefix(filepath.Base(ws.path), "bench-go-file_write-") {
+		t.Errorf("workspace %s is not a bench-go-file_write- directory in %s", ws.path, r.workdir)
+	}
+	if ws.output != filepath.Join(ws.path, "output.txt") {
+		t.Errorf("output is %s, want output.txt in the workspace", ws.output)
+	}
+
+	args := ws.args(map[string]string{"size": "2048"})
+	if want := map[string]string{"size": "2048", "output": ws.output}; !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %v, want %v", args, want)
+	}
+	env := ws.env([]string{"HOME=/root"})
+	if want := []string{"HOME=/root", "TMPDIR=" + ws.path, "BENCHMARK_WORKDIR=" + ws.path}; !reflect.DeepEqual(env, want) {
+		t.Errorf("env = %v, want %v", env, want)
+	}
+
+	if err := os.WriteFile(ws.output, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ws.remove()
+	ws.remove()
+	if _, err := os.Stat(ws.path); !os.IsNotExist(err) {
+		t.Errorf("workspace %s still exists after remove", ws.path)
+	}
+}
+
+func TestNewWorkspaceChecksFreeSpace(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := newWorkspace(dir, "go", "file_write", 1<<60); err == nil {
+		t.Fatal("expected an error for a footprint larger than the filesystem")
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("a rejected workspace left %d entries behind", len(entries))
+	}
+}
+
+func TestExpectedFootprint(t *testing.T) {
+	tests := []struct {
+		test   string
+		params map[string]string
+		want   int64
+	}{
+		{test: "file_write", params: map[string]string{"size": "4096"}, want: 4096},
+		{test: "file_write_lines", params: map[string]string{"lines": "10"}, want: 10 * textLineBytes},
+		{test: "json_write", params: map[string]string{"size": "10"}, want: 10 * jsonItemBytes},
+		{test: "json_write_lines", params: map[string]string{"lines": "10"}, want: 10 * jsonLineBytes},
+		{test: "file_concurrent", params: map[string]string{"workers": "2", "file-size": "1024"}, want: 2048},
+		{test: "file_concurrent", params: map[string]string{}, want: 4 * 16 * 1024 * 1024},
+		{test: "json_read", params: map[string]string{}, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := expectedFootprint(tt.test, tt.params); got != tt.want {
+			t.Errorf("%s %v: got %d, want %d", tt.test, tt.params, got, tt.want)
+		}
+	}
+}
@@ -74,11 +74,11 @@ const REPORT_CONFIG = {
         primaryMetric: 'maxConcurrentClients',
         metricUnit: 'clients',
         sortOrder: 'desc',
-        description: 'Finds the maximum number of concurrent connections each runtime can handle before performance degrades. Tests scalability under increasing load.',
+        description: 'Finds the maximum number of concurrent clients each runtime serves while staying within a p99 latency and error-rate SLO.',
         betterWhen: 'higher',
         hasNoOpsMetric: false,
         isHttpServer: false,
-        calculation: 'Data: Gradually increase concurrent clients until RPS drops below 90% of peak • Calculation: Maximum concurrent clients before performance threshold breach'
+        calculation: 'Data: Double concurrent clients until the SLO breaks, then binary search between the last passing and first failing level • Calculation: Highest client count within the SLO'
     },
     'concurrency_test': {
         category: 'Computational Performance',
@@ -165,8 +165,9 @@ class ModernHTMLReportGenerator {
         const allResults = this.mergeAllReports(reports);
         const aggregatedResults = this.aggregateAndCategorizeResults(allResults);
         const topPerformers = this.calculateTopPerformers(allResults);
+        const scaling = this.mergeScaling(reports);
         
-        return this.htmlTemplate(latestReport, aggregatedResults, topPerformers);
+        return this.htmlTemplate(latestReport, aggregatedResults, topPerformers, scaling);
     }
 
     // ===== NEW METHOD: Merge results from all reports =====
@@ -182,6 +183,8 @@ class ModernHTMLReportGenerator {
         // Remove duplicates based on tech+test combination (keep the latest)
         const resultMap = new Map();
         allResults.forEach(result => {
+            // Swept results are shown as curves in the scaling section
+            if (result.sweep) return;
             const key = `${result.tech}-${result.test}`;
             if (!resultMap.has(key) || new Date(result.timestamp || 0) > new Date(resultMap.get(key).timestamp || 0)) {
                 resultMap.set(key, result);
@@ -191,25 +194,39 @@ class ModernHTMLReportGenerator {
         return Array.from(resultMap.values());
     }
 
+    // Scaling curves per test, taken from the latest report that swept it
+    mergeScaling(reports) {
+        const scalingMap = new Map();
+        reports.forEach(report => {
+            (report.scaling || []).forEach(view => {
+                if (!scalingMap.has(view.test)) {
+                    scalingMap.set(view.test, view);
+                }
+            });
+        });
+        return Array.from(scalingMap.values());
+    }
+
     // ===== HTML TEMPLATES (Separation of Concerns) =====
 
-    htmlTemplate(report, aggregatedResults, topPerformers) {
+    htmlTemplate(report, aggregatedResults, topPerformers, scaling) {
         return `<!DOCTYPE html>
 <html lang="en">
 ${this.headTemplate()}
 <body>
     <div class="app">
         ${this.headerTemplate(report)}
-        ${this.navigationTemplate()}
+        ${this.navigationTemplate(scaling)}
         <main class="main">
             ${this.heroSectionTemplate(topPerformers)}
             ${this.systemInfoTemplate(report.metadata)}
             ${this.categorizedSectionsTemplate(aggregatedResults)}
+            ${this.scalingSectionTemplate(scaling)}
             ${this.rawDataTemplate(report)}
         </main>
         ${this.footerTemplate()}
     </div>
-    ${this.scriptsTemplate(aggregatedResults)}
+    ${this.scriptsTemplate(aggregatedResults, scaling)}
 </body>
 </html>`;
     }
@@ -257,7 +274,7 @@ ${this.headTemplate()}
 </header>`;
     }
 
-    navigationTemplate() {
+    navigationTemplate(scaling) {
         const categories = [...new Set(Object.values(REPORT_CONFIG).map(config => config.category))];
         
         return `<nav class="navigation">
@@ -268,6 +285,7 @@ ${this.headTemplate()}
             ${categories.map(category => 
                 `<a href="#${this.slugify(category)}" class="nav-link">${category}</a>`
             ).join('')}
+            ${scaling.length > 0 ? '<a href="#scaling" class="nav-link">Scaling</a>' : ''}
             <a href="#raw-data" class="nav-link">Raw Data</a>
         </div>
     </div>
@@ -440,6 +458,73 @@ ${this.headTemplate()}
 </div>`;
     }
 
+    scalingSectionTemplate(scaling) {
+        if (scaling.length === 0) return '';
+
+        return `<section class="section" id="scaling">
+    <div class="container">
+        <div class="section-header">
+            <h2 class="section-title">Scaling</h2>
+            <p class="section-subtitle">How each technology's results change across swept parameters</p>
+        </div>
+        
+        <div class="tests-grid">
+            ${scaling.map(view => this.scalingCardTemplate(view)).join('')}
+        </div>
+    </div>
+</section>`;
+    }
+
+    scalingCardTemplate(view) {
+        const config = REPORT_CONFIG[view.test] || { title: view.test, primaryMetric: 'operationsPerSecond', metricUnit: 'ops/sec' };
+        const labels = this.scalingLabels(view);
+
+        return `<div class="test-card" data-test-type="${view.test}">
+    <div class="test-header">
+        <h3 class="test-title">${config.title}</h3>
+        <p class="test-description">${config.metricUnit} across ${view.parameters.join(' × ')}</p>
+    </div>
+    
+    <div class="chart-container">
+        <canvas id="scaling-${view.test}"></canvas>
+    </div>
+    
+    <div class="results-table">
+        <div class="table-header">
+            <div class="table-cell">Technology</div>
+            ${labels.map(label => `<div class="table-cell">${label}</div>`).join('')}
+        </div>
+        ${view.curves.map(curve => `
+            <div class="table-row" style="border-left: 4px solid ${TECH_COLORS[curve.tech]?.primary || '#eebbc3'}">
+                <div class="table-cell tech-cell">
+                    <span class="tech-icon">${this.getTechIcon(curve.tech)}</span>
+                    <span class="tech-name">${curve.tech}</span>
+                </div>
+                ${labels.map(label => {
+                    const point = curve.points.find(p => this.scalingLabel(view, p) === label);
+                    return `<div class="table-cell metric-cell">${point ? (point.metrics[config.primaryMetric] || 0).toLocaleString() : '-'}</div>`;
+                }).join('')}
+            </div>
+        `).join('')}
+    </div>
+</div>`;
+    }
+
+    // Parameter sets in the order the first technology ran them
+    scalingLabels(view) {
+        const labels = [];
+        view.curves.forEach(curve => curve.points.forEach(point => {
+            const label = this.scalingLabel(view, point);
+            if (!labels.includes(label)) labels.push(label);
+        }));
+        return labels;
+    }
+
+    scalingLabel(view, point) {
+        if (view.parameters.length === 1) return point.parameters[view.parameters[0]];
+        return view.parameters.map(key => `${key}=${point.parameters[key]}`).join(' ');
+    }
+
     rawDataTemplate(report) {
         return `<section class="section" id="raw-data">
     <div class="container">
@@ -1225,7 +1310,7 @@ ${this.headTemplate()}
         `;
     }
 
-    scriptsTemplate(categorizedResults) {
+    scriptsTemplate(categorizedResults, scaling) {
         const chartDataByTest = {};
         
         Object.entries(categorizedResults).forEach(([category, results]) => {
@@ -1248,6 +1333,24 @@ ${this.headTemplate()}
             });
         });
 
+        const scalingData = scaling.map(view => {
+            const config = REPORT_CONFIG[view.test] || { primaryMetric: 'operationsPerSecond', metricUnit: 'ops/sec' };
+            const labels = this.scalingLabels(view);
+            return {
+                test: view.test,
+                labels,
+                metricUnit: config.metricUnit,
+                datasets: view.curves.map(curve => ({
+                    label: curve.tech,
+                    color: TECH_COLORS[curve.tech]?.primary || '#E91E63',
+                    data: labels.map(label => {
+                        const point = curve.points.find(p => this.scalingLabel(view, p) === label);
+                        return point ? point.metrics[config.primaryMetric] || 0 : null;
+                    })
+                }))
+            };
+        });
+
         return `<script>
          // Chart.js Configuration with This Section's Hues Theme
          Chart.defaults.color = '#b8c1ec';
@@ -1329,6 +1432,45 @@ ${this.headTemplate()}
              });
          });
          
+         // Scaling curves
+         const scalingData = ${JSON.stringify(scalingData)};
+         
+         scalingData.forEach(data => {
+             const canvas = document.getElementById('scaling-' + data.test);
+             if (!canvas) return;
+             
+             new Chart(canvas, {
+                 type: 'line',
+                 data: {
+                     labels: data.labels,
+                     datasets: data.datasets.map(dataset => ({
+                         label: dataset.label,
+                         data: dataset.data,
+                         borderColor: dataset.color,
+                         backgroundColor: dataset.color + '30',
+                         borderWidth: 3,
+                         pointRadius: 5,
+                         tension: 0.2,
+                         spanGaps: true
+                     }))
+                 },
+                 options: {
+                     ...chartOptions,
+                     plugins: {
+                         ...chartOptions.plugins,
+                         legend: { display: true },
+                         tooltip: {
+                             ...chartOptions.plugins.tooltip,
+                             displayColors: true,
+                             callbacks: {
+                                 label: (context) => context.dataset.label + ': ' + context.parsed.y.toLocaleString() + ' ' + data.metricUnit
+                             }
+                         }
+                     }
+                 }
+             });
+         });
+         
          // Navigation interactions
          document.querySelectorAll('.nav-link').forEach(link => {
              link.addEventListener('click', function(e) {
@@ -30,7 +30,7 @@ echo "✅ Orchestrator built successfully"
 # Generate test data
 echo "📁 Generating test data..."
 cd ..
-node scripts/generate_test_data.js
+./orchestrator/benchmark-cli data generate
 
 # Create reports directory
 echo "📊 Creating reports directory..."